@@ -10,6 +10,9 @@ import (
 	"pr-reviewer-assigment-service/internal/config"
 	"pr-reviewer-assigment-service/internal/http"
 	"time"
+
+	// часовые пояса команд (business_time_zone) нужны и в образе без tzdata
+	_ "time/tzdata"
 )
 
 func main() {
@@ -34,12 +37,18 @@ func main() {
 	}
 	defer app.Close()
 
+	app.StartScheduler(context.Background(), cfg.Scheduler)
+	app.StartWebhooks(context.Background(), cfg.Webhooks)
+	app.StartEventListener(context.Background())
+
 	server := http.RegisterRoutes(http.RoutesHandlers{
-		Router:       app.Router,
-		UserHandler:  app.UserHandler,
-		TeamHandler:  app.TeamHandler,
-		PrHandler:    app.PRHandler,
-		StatsHandler: app.StatsHandler,
+		Router:         app.Router,
+		UserHandler:    app.UserHandler,
+		TeamHandler:    app.TeamHandler,
+		PrHandler:      app.PRHandler,
+		StatsHandler:   app.StatsHandler,
+		WebhookHandler: app.WebhookHandler,
+		EventsHandler:  app.EventsHandler,
 	})
 
 	addr := fmt.Sprintf("%s:%d", cfg.HTTP.Host, cfg.HTTP.Port)
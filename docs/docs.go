@@ -15,7 +15,58 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/pullRequest/create": {
+        "/events": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Читать поток доменных событий (long-poll)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Курсор next_cursor из предыдущего ответа",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер пачки (по умолчанию 100, максимум 1000)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Сколько секунд ждать новых событий (по умолчанию 30, максимум 60, 0 - не ждать)",
+                        "name": "wait",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "События после курсора",
+                        "schema": {
+                            "$ref": "#/definitions/events.EventsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_CURSOR / INVALID_WAIT",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/addReviewer": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -26,39 +77,45 @@ const docTemplate = `{
                 "tags": [
                     "PullRequests"
                 ],
-                "summary": "Создать PR и автоматически назначить до 2 ревьюверов из команды автора",
+                "summary": "Вручную добавить ревьювера в открытый PR",
                 "parameters": [
                     {
-                        "description": "Параметры для создания PR",
+                        "type": "string",
+                        "description": "Ожидаемая версия PR (ETag из предыдущего ответа)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "PR и добавляемый ревьювер",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pull_requests.CreatePRRequest"
+                            "$ref": "#/definitions/pull_requests.ChangeReviewerRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Созданный PR с назначенными ревьюверами",
+                    "200": {
+                        "description": "PR с новым списком ревьюверов",
                         "schema": {
-                            "$ref": "#/definitions/pull_requests.CreatePRResponse"
+                            "$ref": "#/definitions/pull_requests.ChangeReviewerResponse"
                         }
                     },
                     "400": {
-                        "description": "INVALID_JSON",
+                        "description": "INVALID_JSON / INVALID_VERSION / MISSING_FIELD",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "NOT_FOUND (author or team not found)",
+                        "description": "NOT_FOUND",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "PR_EXISTS",
+                        "description": "PR_MERGED / PR_CLOSED / REVIEWER_IS_AUTHOR / REVIEWER_INACTIVE / REVIEWER_UNAVAILABLE / REVIEWER_EXCLUDED / ALREADY_ASSIGNED / CONFLICT_VERSION",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -72,7 +129,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/pullRequest/merge": {
+        "/pullRequest/close": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -83,23 +140,23 @@ const docTemplate = `{
                 "tags": [
                     "PullRequests"
                 ],
-                "summary": "Пометить PR как MERGED (идемпотентная операция)",
+                "summary": "Закрыть PR без merge (идемпотентная операция)",
                 "parameters": [
                     {
-                        "description": "Идентификатор PR для merge",
+                        "description": "Идентификатор PR",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pull_requests.MergePRRequest"
+                            "$ref": "#/definitions/pull_requests.ChangeStatusPRRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "PR успешно помечен как MERGED",
+                        "description": "PR в статусе CLOSED",
                         "schema": {
-                            "$ref": "#/definitions/pull_requests.MergePRResponse"
+                            "$ref": "#/definitions/pull_requests.ChangeStatusPRResponse"
                         }
                     },
                     "400": {
@@ -114,6 +171,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "PR_MERGED",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "INTERNAL_ERROR",
                         "schema": {
@@ -123,7 +186,7 @@ const docTemplate = `{
                 }
             }
         },
-        "/pullRequest/reassign": {
+        "/pullRequest/create": {
             "post": {
                 "consumes": [
                     "application/json"
@@ -134,39 +197,39 @@ const docTemplate = `{
                 "tags": [
                     "PullRequests"
                 ],
-                "summary": "Переназначить ревьювера на другого из его команды",
+                "summary": "Создать PR и автоматически назначить ревьюверов из команды автора",
                 "parameters": [
                     {
-                        "description": "PR и старый ревьювер",
+                        "description": "Параметры для создания PR",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/pull_requests.ReassignPRRequest"
+                            "$ref": "#/definitions/pull_requests.CreatePRRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Успешное переназначение ревьювера",
+                    "201": {
+                        "description": "Созданный PR с назначенными ревьюверами (insufficient_reviewers, если кандидатов не хватило)",
                         "schema": {
-                            "$ref": "#/definitions/pull_requests.ReassignPRResponse"
+                            "$ref": "#/definitions/pull_requests.CreatePRResponse"
                         }
                     },
                     "400": {
-                        "description": "INVALID_JSON",
+                        "description": "INVALID_JSON / INVALID_REVIEWERS_COUNT / INVALID_TAG",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "NOT_FOUND",
+                        "description": "NOT_FOUND (author or team not found)",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "PR_MERGED / NO_CANDIDATE / NOT_ASSIGNED",
+                        "description": "PR_EXISTS",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -180,42 +243,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/stats/users": {
+        "/pullRequest/get": {
             "get": {
-                "description": "Возвращает список пользователей и количество назначенных им PR с пагинацией.",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "statistics"
+                    "PullRequests"
                 ],
-                "summary": "Статистика назначений по пользователям",
+                "summary": "Получить PR с ревьюверами",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Лимит выборки (по умолчанию 50, максимум 100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Смещение выборки (по умолчанию 0)",
-                        "name": "offset",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Идентификатор PR",
+                        "name": "pull_request_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "PR с ревьюверами",
                         "schema": {
-                            "$ref": "#/definitions/statistics.UserStatsResponse"
+                            "$ref": "#/definitions/pull_requests.GetPRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "INTERNAL_ERROR",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -223,38 +289,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/team/add": {
-            "post": {
-                "consumes": [
-                    "application/json"
-                ],
+        "/pullRequest/history": {
+            "get": {
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "PullRequests"
                 ],
-                "summary": "Создать команду с участниками (создаёт/обновляет пользователей)",
+                "summary": "Получить историю назначений ревьюверов PR",
                 "parameters": [
                     {
-                        "description": "Команда и её участники",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/teams.TeamAddRequest"
-                        }
+                        "type": "string",
+                        "description": "Идентификатор PR",
+                        "name": "pull_request_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Созданная/обновлённая команда",
+                    "200": {
+                        "description": "История назначений",
                         "schema": {
-                            "$ref": "#/definitions/teams.TeamAddResponse"
+                            "$ref": "#/definitions/pull_requests.HistoryResponse"
                         }
                     },
                     "400": {
-                        "description": "INVALID_JSON",
+                        "description": "MISSING_FIELD",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -265,12 +326,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "USERS_TEAM_EXISTS",
-                        "schema": {
-                            "$ref": "#/definitions/response.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "INTERNAL_ERROR",
                         "schema": {
@@ -280,37 +335,98 @@ const docTemplate = `{
                 }
             }
         },
-        "/team/get": {
+        "/pullRequest/list": {
             "get": {
-                "description": "Возвращает состав команды по её имени.",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Teams"
+                    "PullRequests"
                 ],
-                "summary": "Получить команду с участниками",
+                "summary": "Список PR с фильтрами и пагинацией",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Уникальное имя команды",
+                        "description": "OPEN / MERGED / CLOSED",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Автор PR",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Назначенный ревьювер",
+                        "name": "reviewer_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Команда автора PR",
                         "name": "team_name",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Создан не раньше (RFC 3339)",
+                        "name": "created_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Создан раньше (RFC 3339)",
+                        "name": "created_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Смержен не раньше (RFC 3339)",
+                        "name": "merged_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Смержен раньше (RFC 3339)",
+                        "name": "merged_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at (по умолчанию) / pull_request_id",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desc (по умолчанию) / asc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Размер страницы (по умолчанию 50, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor из предыдущего ответа",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Команда и её участники",
+                        "description": "Страница PR",
                         "schema": {
-                            "$ref": "#/definitions/teams.TeamResponse"
+                            "$ref": "#/definitions/pull_requests.ListPRResponse"
                         }
                     },
-                    "404": {
-                        "description": "NOT_FOUND / TEAM_NOT_FOUND",
+                    "400": {
+                        "description": "INVALID_QUERY / INVALID_CURSOR",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -324,9 +440,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/getReview": {
-            "get": {
-                "description": "Возвращает список PR'ов, в которых user_id указан как ревьювер",
+        "/pullRequest/merge": {
+            "post": {
                 "consumes": [
                     "application/json"
                 ],
@@ -334,33 +449,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "PullRequests"
                 ],
-                "summary": "Получить PR'ы, где пользователь назначен ревьювером",
+                "summary": "Пометить PR как MERGED (идемпотентная операция)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Идентификатор пользователя",
-                        "name": "user_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Идентификатор PR для merge",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.MergePRRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список PR'ов пользователя",
+                        "description": "PR успешно помечен как MERGED",
                         "schema": {
-                            "$ref": "#/definitions/users.GetReviewResponse"
+                            "$ref": "#/definitions/pull_requests.MergePRResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос (нет user_id)",
+                        "description": "INVALID_JSON",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR_CLOSED / NOT_APPROVED",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "INTERNAL_ERROR",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
@@ -368,9 +497,8 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/setIsActive": {
+        "/pullRequest/preview": {
             "post": {
-                "description": "Принимает user_id и is_active, обновляет пользователя и возвращает его состояние",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,101 +506,2318 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "PullRequests"
                 ],
-                "summary": "Установить флаг активности пользователя",
+                "summary": "Показать, кого назначит create, ничего не сохраняя",
                 "parameters": [
                     {
-                        "description": "Тело запроса",
+                        "description": "Автор и параметры будущего PR",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/users.SetActiveRequest"
+                            "$ref": "#/definitions/pull_requests.PreviewPRRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновлённый пользователь",
+                        "description": "Выбранные ревьюверы и решение по каждому участнику команды",
                         "schema": {
-                            "$ref": "#/definitions/users.SetIsActiveResponse"
+                            "$ref": "#/definitions/pull_requests.PreviewPRResponse"
                         }
                     },
                     "400": {
-                        "description": "Некорректный запрос",
+                        "description": "INVALID_JSON / INVALID_REVIEWERS_COUNT / INVALID_TAG",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Пользователь не найден",
+                        "description": "NOT_FOUND (author or team not found)",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Внутренняя ошибка сервера",
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/reassign": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Переназначить ревьювера на другого из его команды",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ожидаемая версия PR (ETag из предыдущего ответа)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "PR, старый ревьювер и, при необходимости, новый",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ReassignPRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешное переназначение ревьювера",
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ReassignPRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / INVALID_VERSION",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR_MERGED / PR_CLOSED / NO_CANDIDATE / NOT_ASSIGNED / CONFLICT_VERSION",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/removeReviewer": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Вручную снять ревьювера с открытого PR без замены",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Ожидаемая версия PR (ETag из предыдущего ответа)",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "PR и снимаемый ревьювер",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ChangeReviewerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR с новым списком ревьюверов",
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ChangeReviewerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / INVALID_VERSION / MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR_MERGED / PR_CLOSED / NOT_ASSIGNED / CONFLICT_VERSION",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/reopen": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Переоткрыть закрытый PR (идемпотентная операция)",
+                "parameters": [
+                    {
+                        "description": "Идентификатор PR",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ChangeStatusPRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR в статусе OPEN",
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ChangeStatusPRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR_MERGED",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pullRequest/review": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PullRequests"
+                ],
+                "summary": "Отправить вердикт ревьювера по PR",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Кто отправляет вердикт; должен совпадать с reviewer_id",
+                        "name": "X-Actor-ID",
+                        "in": "header"
+                    },
+                    {
+                        "description": "PR, ревьювер и вердикт",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ReviewPRRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "PR с состояниями ревьюверов",
+                        "schema": {
+                            "$ref": "#/definitions/pull_requests.ReviewPRResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD / INVALID_REVIEW_STATE",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "NOT_REVIEWER",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "PR_MERGED / PR_CLOSED / NOT_ASSIGNED",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
                         "schema": {
                             "$ref": "#/definitions/response.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "pull_requests.CreatePRRequest": {
+        },
+        "/stats/users": {
+            "get": {
+                "description": "Возвращает список пользователей и количество назначенных им PR с пагинацией.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "statistics"
+                ],
+                "summary": "Статистика назначений по пользователям",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Лимит выборки (по умолчанию 50, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Смещение выборки (по умолчанию 0)",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/statistics.UserStatsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/add": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Создать команду с участниками (создаёт/обновляет пользователей)",
+                "parameters": [
+                    {
+                        "description": "Команда и её участники",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/teams.TeamAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданная/обновлённая команда",
+                        "schema": {
+                            "$ref": "#/definitions/teams.TeamAddResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / INVALID_REVIEWERS_COUNT / INVALID_STRATEGY / INVALID_LOAD_METRIC / INVALID_FALLBACK_TEAM / INVALID_CAPACITY / INVALID_TAG / INVALID_REQUIRED_APPROVALS / INVALID_REVIEW_SLA / INVALID_AUTO_REASSIGN / INVALID_BUSINESS_HOURS",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "USERS_TEAM_EXISTS",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/addRule": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Добавить правило подбора ревьюверов для автора",
+                "parameters": [
+                    {
+                        "description": "Правило",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/teams.AddRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Сохранённое правило",
+                        "schema": {
+                            "$ref": "#/definitions/teams.AddRuleResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD / INVALID_RULE",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND / NOT_IN_TEAM",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/deactivateUsers": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Деактивировать участников команды с переназначением их ревью",
+                "parameters": [
+                    {
+                        "description": "Команда и деактивируемые участники",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/teams.DeactivateUsersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Деактивированные участники и итоги переназначения",
+                        "schema": {
+                            "$ref": "#/definitions/teams.DeactivateUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND / NOT_IN_TEAM",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/deleteRule": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Удалить правило подбора ревьюверов",
+                "parameters": [
+                    {
+                        "description": "Команда и правило",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/teams.DeleteRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Правило удалено"
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Правило не найдено",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/get": {
+            "get": {
+                "description": "Возвращает состав команды по её имени, настройки назначения и нагрузку участников (load) по метрике команды.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Получить команду с участниками",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальное имя команды",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Команда и её участники",
+                        "schema": {
+                            "$ref": "#/definitions/teams.TeamResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND / TEAM_NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/getCodeOwners": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Получить правила владения путями команды",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальное имя команды",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Правила команды в порядке задания",
+                        "schema": {
+                            "$ref": "#/definitions/teams.CodeOwnersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/getRules": {
+            "get": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Получить правила подбора ревьюверов команды",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Уникальное имя команды",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Правила команды в порядке добавления",
+                        "schema": {
+                            "$ref": "#/definitions/teams.GetRulesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/overdueReviews": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Получить просроченные ревью участников команды",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Имя команды",
+                        "name": "team_name",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Просроченные ревью, от самых старых",
+                        "schema": {
+                            "$ref": "#/definitions/teams.OverdueReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/team/setCodeOwners": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Teams"
+                ],
+                "summary": "Задать правила владения путями (CODEOWNERS) команды",
+                "parameters": [
+                    {
+                        "description": "Команда и правила",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/teams.SetCodeOwnersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохранённые правила",
+                        "schema": {
+                            "$ref": "#/definitions/teams.CodeOwnersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD / INVALID_CODE_OWNER_RULE",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "NOT_FOUND (команда или владелец не найдены)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/addUnavailability": {
+            "post": {
+                "description": "Сохраняет период [starts_at, ends_at) (RFC 3339) с причиной. Пока период покрывает текущий момент,\nпользователь не выбирается ревьювером, при этом is_active не меняется и вручную возвращать его не нужно.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Запланировать период отсутствия пользователя",
+                "parameters": [
+                    {
+                        "description": "Период отсутствия",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.AddUnavailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Сохранённый период",
+                        "schema": {
+                            "$ref": "#/definitions/users.AddUnavailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD / INVALID_PERIOD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/deleteUnavailability": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Отменить период отсутствия пользователя",
+                "parameters": [
+                    {
+                        "description": "Пользователь и период",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.DeleteUnavailabilityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Период удалён"
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Период не найден",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/getReview": {
+            "get": {
+                "description": "Возвращает список PR'ов, в которых user_id указан как ревьювер (закрытые PR не включаются).\nЕсли у команды пользователя задан review_sla_hours, для каждого PR возвращается срок due_at,\nа overdue = true, если PR открыт, вердикта ещё нет и срок прошёл.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить PR'ы, где пользователь назначен ревьювером",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список PR'ов пользователя",
+                        "schema": {
+                            "$ref": "#/definitions/users.GetReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос (нет user_id)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/getUnavailability": {
+            "get": {
+                "description": "Возвращает текущие и будущие периоды отсутствия пользователя по времени начала",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить периоды отсутствия пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Периоды отсутствия",
+                        "schema": {
+                            "$ref": "#/definitions/users.GetUnavailabilityResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос (нет user_id)",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/overdueReviews": {
+            "get": {
+                "description": "Возвращает назначения user_id в открытых PR без вердикта, по которым истёк SLA его команды\n(review_sla_hours рабочих часов с момента назначения по рабочему дню команды, по умолчанию будни 09:00–18:00 UTC).\nЕсли у команды нет SLA, список пуст.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Получить просроченные ревью пользователя",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Идентификатор пользователя",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Просроченные ревью",
+                        "schema": {
+                            "$ref": "#/definitions/users.OverdueReviewsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/setIsActive": {
+            "post": {
+                "description": "Принимает user_id и is_active, обновляет пользователя и возвращает его состояние.\nПри деактивации все ревью пользователя в открытых PR в той же транзакции переназначаются\nна других активных участников команды автора (как в /pullRequest/reassign).\nВ reassignments возвращается итог по каждому PR: REASSIGNED (new_reviewer_id — замена)\nили NO_CANDIDATE (заменить некем, ревьювер остался в PR).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Установить флаг активности пользователя",
+                "parameters": [
+                    {
+                        "description": "Тело запроса",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.SetActiveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/users.SetIsActiveResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Некорректный запрос",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/update": {
+            "post": {
+                "description": "Меняет имя, лимит открытых ревью (max_open_reviews) и теги экспертизы (tags) пользователя.\nНе переданные поля не меняются, max_open_reviews = 0 снимает лимит, tags = [] очищает теги.\nПользователь, у которого открытых ревью не меньше лимита, не выбирается ревьювером;\nуже назначенные ревью при снижении лимита не снимаются.\nТеги приводятся к нижнему регистру; PR с совпадающими тегами назначаются таким пользователям в первую очередь.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Обновить пользователя",
+                "parameters": [
+                    {
+                        "description": "Тело запроса",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/users.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновлённый пользователь",
+                        "schema": {
+                            "$ref": "#/definitions/users.UpdateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD / INVALID_CAPACITY / INVALID_TAG",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Пользователь не найден",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Внутренняя ошибка сервера",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/add": {
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Подписаться на события назначений",
+                "parameters": [
+                    {
+                        "description": "Подписка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.AddWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Созданная подписка",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.AddWebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / INVALID_WEBHOOK",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/deadLetters": {
+            "get": {
+                "description": "Доставки, исчерпавшие попытки, от новых к старым.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получить недоставленные вебхуки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Только доставки этой подписки",
+                        "name": "webhook_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Лимит выборки (по умолчанию 50, максимум 100)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Недоставленные вебхуки",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.DeadLettersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "INVALID_WEBHOOK_ID",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/delete": {
+            "post": {
+                "description": "Недоставленные события подписки удаляются вместе с ней.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Удалить подписку на вебхуки",
+                "parameters": [
+                    {
+                        "description": "Подписка",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.DeleteWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Подписка удалена"
+                    },
+                    "400": {
+                        "description": "INVALID_JSON / MISSING_FIELD",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Подписка не найдена",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/list": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Получить подписки на вебхуки",
+                "responses": {
+                    "200": {
+                        "description": "Подписки в порядке создания, без секретов",
+                        "schema": {
+                            "$ref": "#/definitions/webhooks.ListWebhooksResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "INTERNAL_ERROR",
+                        "schema": {
+                            "$ref": "#/definitions/response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "events.EventResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "cursor": {
+                    "description": "Cursor - позиция сразу после события.",
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "payload": {
+                    "description": "Payload - описание события, набор полей зависит от type.",
+                    "type": "object"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "events.EventsResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/events.EventResponse"
+                    }
+                },
+                "held_back": {
+                    "description": "HeldBack - сколько уже закоммиченных событий после next_cursor задержано открытой более ранней транзакцией.",
+                    "type": "integer"
+                },
+                "lag_seconds": {
+                    "description": "LagSeconds - сколько секунд ждёт самое раннее задержанное событие, 0 - задержанных нет.",
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor передаётся как after в следующем запросе; если событий нет, равен переданному after.",
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.AssignmentEventResponse": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous_reviewer_id": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.CandidateResponse": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "load": {
+                    "type": "number"
+                },
+                "max_open_reviews": {
+                    "type": "integer"
+                },
+                "open_reviews": {
+                    "type": "integer"
+                },
+                "pattern": {
+                    "type": "string"
+                },
+                "picked": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.ChangeReviewerRequest": {
+            "type": "object",
+            "properties": {
+                "expected_version": {
+                    "description": "ExpectedVersion - версия PR, которую видел клиент; альтернатива заголовку If-Match.",
+                    "type": "integer"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.ChangeReviewerResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
+                }
+            }
+        },
+        "pull_requests.ChangeStatusPRRequest": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.ChangeStatusPRResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestDetailsResponse"
+                }
+            }
+        },
+        "pull_requests.CodeOwnerReviewer": {
+            "type": "object",
+            "properties": {
+                "pattern": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.CreatePRRequest": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "changed_files": {
+                    "description": "ChangedFiles - изменённые файлы; их владельцы по правилам команды назначаются первыми.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "reviewers_count": {
+                    "description": "ReviewersCount переопределяет required_reviewers команды автора.",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tags - теги PR; предпочтение отдаётся ревьюверам с хотя бы одним совпадающим тегом.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "pull_requests.CreatePRResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
+                }
+            }
+        },
+        "pull_requests.FallbackReviewer": {
+            "type": "object",
+            "properties": {
+                "team_name": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.GetPRResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestDetailsResponse"
+                }
+            }
+        },
+        "pull_requests.HistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.AssignmentEventResponse"
+                    }
+                },
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.ListPRResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.PullRequestDetailsResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "description": "NextCursor передаётся в cursor для следующей страницы; отсутствует на последней странице.",
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pull_requests.MergePRRequest": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.MergePRResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
+                }
+            }
+        },
+        "pull_requests.PreviewPRRequest": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "changed_files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reviewers_count": {
+                    "description": "ReviewersCount переопределяет required_reviewers команды автора.",
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "pull_requests.PreviewPRResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "candidates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.CandidateResponse"
+                    }
+                },
+                "code_owner_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.CodeOwnerReviewer"
+                    }
+                },
+                "fallback_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.FallbackReviewer"
+                    }
+                },
+                "insufficient_reviewers": {
+                    "type": "boolean"
+                },
+                "required_reviewers": {
+                    "type": "integer"
+                },
+                "rule_effects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.RuleEffect"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.PullRequestDetailsResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "merged_at": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "required_reviewers": {
+                    "type": "integer"
+                },
+                "reviewers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.ReviewerResponse"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pull_requests.PullRequestResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "code_owner_reviewers": {
+                    "description": "CodeOwnerReviewers заполняется при create, если среди ревьюверов есть владельцы изменённых файлов.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.CodeOwnerReviewer"
+                    }
+                },
+                "fallback_reviewers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.FallbackReviewer"
+                    }
+                },
+                "insufficient_reviewers": {
+                    "type": "boolean"
+                },
+                "mergedAt": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "replaced_by": {
+                    "type": "string"
+                },
+                "required_reviewers": {
+                    "description": "заполняются при назначении ревьюверов (create/reassign)",
+                    "type": "integer"
+                },
+                "rule_effects": {
+                    "description": "RuleEffects заполняется при create/reassign, если на выбор повлияли правила команды.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/pull_requests.RuleEffect"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "pull_requests.ReassignPRRequest": {
+            "type": "object",
+            "properties": {
+                "expected_version": {
+                    "description": "ExpectedVersion - версия PR, которую видел клиент; альтернатива заголовку If-Match.",
+                    "type": "integer"
+                },
+                "new_reviewer_id": {
+                    "description": "NewReviewerID - кому передать ревью; если не задан, замена подбирается автоматически.",
+                    "type": "string"
+                },
+                "old_reviewer_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.ReassignPRResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
+                }
+            }
+        },
+        "pull_requests.ReviewPRRequest": {
+            "type": "object",
+            "properties": {
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State - APPROVED, CHANGES_REQUESTED или DISMISSED.",
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.ReviewPRResponse": {
+            "type": "object",
+            "properties": {
+                "pr": {
+                    "$ref": "#/definitions/pull_requests.PullRequestDetailsResponse"
+                }
+            }
+        },
+        "pull_requests.ReviewerResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "state": {
+                    "description": "State - PENDING, APPROVED, CHANGES_REQUESTED или DISMISSED.",
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "pull_requests.RuleEffect": {
+            "type": "object",
+            "properties": {
+                "effect": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ErrBodyResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "response.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/response.ErrBodyResponse"
+                }
+            }
+        },
+        "statistics.UserStatItemResponse": {
+            "type": "object",
+            "properties": {
+                "assignments_count": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "statistics.UserStatsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/statistics.UserStatItemResponse"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "teams.AddRuleRequest": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "Type - NEVER_REVIEWS (нужен reviewer_id) или REQUIRES_TAG (нужен tag).",
+                    "type": "string"
+                }
+            }
+        },
+        "teams.AddRuleResponse": {
+            "type": "object",
+            "properties": {
+                "rule": {
+                    "$ref": "#/definitions/teams.RuleResponse"
+                }
+            }
+        },
+        "teams.CodeOwnerRule": {
+            "type": "object",
+            "properties": {
+                "owner_team": {
+                    "type": "string"
+                },
+                "owner_user_id": {
+                    "type": "string"
+                },
+                "pattern": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.CodeOwnersResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.CodeOwnerRule"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.DeactivateUsersRequest": {
+            "type": "object",
+            "properties": {
+                "team_name": {
+                    "type": "string"
+                },
+                "user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "teams.DeactivateUsersResponse": {
+            "type": "object",
+            "properties": {
+                "deactivated_user_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "reassignments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.ReassignmentResponse"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.DeleteRuleRequest": {
+            "type": "object",
+            "properties": {
+                "rule_id": {
+                    "type": "integer"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.GetRulesResponse": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.RuleResponse"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.Member": {
+            "type": "object",
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "load": {
+                    "description": "Load - нагрузка по метрике команды, заполняется только в ответе /team/get.",
+                    "type": "number"
+                },
+                "max_open_reviews": {
+                    "description": "MaxOpenReviews - лимит открытых ревью; в запросе не передан - без изменений, 0 - снять лимит.",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tags - области экспертизы; в запросе не передано - без изменений, [] - очистить.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.OverdueReviewResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "string"
+                },
+                "due_at": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.OverdueReviewsResponse": {
+            "type": "object",
+            "properties": {
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.OverdueReviewResponse"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.ReassignmentResponse": {
+            "type": "object",
+            "properties": {
+                "new_reviewer_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "replaced_user_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.RuleResponse": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "reviewer_id": {
+                    "type": "string"
+                },
+                "rule_id": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.SetCodeOwnersRequest": {
+            "type": "object",
+            "properties": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.CodeOwnerRule"
+                    }
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.TeamAddRequest": {
+            "type": "object",
+            "properties": {
+                "auto_reassign_hours": {
+                    "description": "AutoReassignHours - порог автоматического переназначения в рабочих часах; не передано - без изменений, 0 - отключить.",
+                    "type": "integer"
+                },
+                "business_day_end": {
+                    "type": "integer"
+                },
+                "business_day_start": {
+                    "description": "BusinessDayStart, BusinessDayEnd - часы начала и конца рабочего дня (0..24, по умолчанию 9 и 18);\nне передано - без изменений.",
+                    "type": "integer"
+                },
+                "business_time_zone": {
+                    "description": "BusinessTimeZone - часовой пояс рабочего дня (IANA, по умолчанию UTC); не передано - без изменений.",
+                    "type": "string"
+                },
+                "fallback_teams": {
+                    "description": "FallbackTeams - резервные команды по приоритету; не передано - без изменений, [] - очистить.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "load_half_life_hours": {
+                    "type": "integer"
+                },
+                "load_metric": {
+                    "type": "string"
+                },
+                "load_window_hours": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.Member"
+                    }
+                },
+                "required_approvals": {
+                    "description": "RequiredApprovals - одобрений для merge; не передано - без изменений, 0 - без проверки.",
+                    "type": "integer"
+                },
+                "required_reviewers": {
+                    "type": "integer"
+                },
+                "review_sla_hours": {
+                    "description": "ReviewSLAHours - SLA ревью в рабочих часах; не передано - без изменений, 0 - снять SLA.",
+                    "type": "integer"
+                },
+                "selection_strategy": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "teams.TeamAddResponse": {
+            "type": "object",
+            "properties": {
+                "team": {
+                    "$ref": "#/definitions/teams.TeamResponse"
+                }
+            }
+        },
+        "teams.TeamResponse": {
+            "type": "object",
+            "properties": {
+                "auto_reassign_hours": {
+                    "type": "integer"
+                },
+                "business_day_end": {
+                    "type": "integer"
+                },
+                "business_day_start": {
+                    "type": "integer"
+                },
+                "business_time_zone": {
+                    "type": "string"
+                },
+                "fallback_teams": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "load_half_life_hours": {
+                    "type": "integer"
+                },
+                "load_metric": {
+                    "type": "string"
+                },
+                "load_window_hours": {
+                    "type": "integer"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/teams.Member"
+                    }
+                },
+                "required_approvals": {
+                    "type": "integer"
+                },
+                "required_reviewers": {
+                    "type": "integer"
+                },
+                "review_sla_hours": {
+                    "type": "integer"
+                },
+                "selection_strategy": {
+                    "type": "string"
+                },
+                "team_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "users.AddUnavailabilityRequest": {
             "type": "object",
             "properties": {
-                "author_id": {
+                "ends_at": {
                     "type": "string"
                 },
-                "pull_request_id": {
+                "reason": {
                     "type": "string"
                 },
-                "pull_request_name": {
+                "starts_at": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "pull_requests.CreatePRResponse": {
+        "users.AddUnavailabilityResponse": {
             "type": "object",
             "properties": {
-                "pr": {
-                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
+                "unavailability": {
+                    "$ref": "#/definitions/users.UnavailabilityResponse"
                 }
             }
         },
-        "pull_requests.MergePRRequest": {
+        "users.DeleteUnavailabilityRequest": {
             "type": "object",
             "properties": {
-                "pull_request_id": {
+                "unavailability_id": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "pull_requests.MergePRResponse": {
+        "users.GetReviewResponse": {
             "type": "object",
             "properties": {
-                "pr": {
-                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
+                "pull_requests": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.PullRequestResponse"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
                 }
             }
         },
-        "pull_requests.PullRequestResponse": {
+        "users.GetUnavailabilityResponse": {
             "type": "object",
             "properties": {
-                "assigned_reviewers": {
+                "unavailability": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/users.UnavailabilityResponse"
                     }
                 },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "users.OverdueReviewResponse": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "type": "string"
+                },
                 "author_id": {
                     "type": "string"
                 },
-                "mergedAt": {
+                "due_at": {
                     "type": "string"
                 },
                 "pull_request_id": {
@@ -481,200 +2826,279 @@ const docTemplate = `{
                 "pull_request_name": {
                     "type": "string"
                 },
-                "replaced_by": {
-                    "type": "string"
-                },
-                "status": {
+                "reviewer_id": {
                     "type": "string"
                 }
             }
         },
-        "pull_requests.ReassignPRRequest": {
+        "users.OverdueReviewsResponse": {
             "type": "object",
             "properties": {
-                "old_reviewer_id": {
-                    "type": "string"
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/users.OverdueReviewResponse"
+                    }
                 },
-                "pull_request_id": {
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "pull_requests.ReassignPRResponse": {
-            "type": "object",
-            "properties": {
-                "pr": {
-                    "$ref": "#/definitions/pull_requests.PullRequestResponse"
-                }
-            }
-        },
-        "response.ErrBodyResponse": {
+        "users.PullRequestResponse": {
             "type": "object",
             "properties": {
-                "code": {
+                "author_id": {
                     "type": "string"
                 },
-                "message": {
+                "due_at": {
+                    "type": "string"
+                },
+                "overdue": {
+                    "description": "Overdue - по ревью истёк SLA команды пользователя.",
+                    "type": "boolean"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "pull_request_name": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "response.ErrorResponse": {
+        "users.ReassignmentResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "$ref": "#/definitions/response.ErrBodyResponse"
+                "new_reviewer_id": {
+                    "type": "string"
+                },
+                "pull_request_id": {
+                    "type": "string"
+                },
+                "replaced_user_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
                 }
             }
         },
-        "statistics.UserStatItemResponse": {
+        "users.SetActiveRequest": {
             "type": "object",
             "properties": {
-                "assignments_count": {
-                    "type": "integer"
+                "is_active": {
+                    "type": "boolean"
                 },
                 "user_id": {
                     "type": "string"
-                },
-                "username": {
-                    "type": "string"
                 }
             }
         },
-        "statistics.UserStatsResponse": {
+        "users.SetIsActiveResponse": {
             "type": "object",
             "properties": {
-                "items": {
+                "reassignments": {
+                    "description": "Reassignments - отчёт о переназначении открытых ревью, заполняется при деактивации.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/statistics.UserStatItemResponse"
+                        "$ref": "#/definitions/users.ReassignmentResponse"
                     }
                 },
-                "limit": {
-                    "type": "integer"
-                },
-                "offset": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
+                "user": {
+                    "$ref": "#/definitions/users.UserResponse"
                 }
             }
         },
-        "teams.Member": {
+        "users.UnavailabilityResponse": {
             "type": "object",
             "properties": {
-                "is_active": {
-                    "type": "boolean"
+                "ends_at": {
+                    "type": "string"
                 },
-                "user_id": {
+                "reason": {
                     "type": "string"
                 },
-                "username": {
+                "starts_at": {
+                    "type": "string"
+                },
+                "unavailability_id": {
+                    "type": "integer"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "teams.TeamAddRequest": {
+        "users.UpdateUserRequest": {
             "type": "object",
             "properties": {
-                "members": {
+                "max_open_reviews": {
+                    "description": "MaxOpenReviews - лимит открытых ревью: не передан - без изменений, 0 - снять лимит.",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tags - области экспертизы: не передано - без изменений, [] - очистить.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/teams.Member"
+                        "type": "string"
                     }
                 },
-                "team_name": {
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "teams.TeamAddResponse": {
+        "users.UpdateUserResponse": {
             "type": "object",
             "properties": {
-                "team": {
-                    "$ref": "#/definitions/teams.TeamResponse"
+                "user": {
+                    "$ref": "#/definitions/users.UserResponse"
                 }
             }
         },
-        "teams.TeamResponse": {
+        "users.UserResponse": {
             "type": "object",
             "properties": {
-                "members": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_open_reviews": {
+                    "description": "MaxOpenReviews - лимит открытых ревью, отсутствует, если лимит не задан.",
+                    "type": "integer"
+                },
+                "tags": {
+                    "description": "Tags - области экспертизы пользователя.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/teams.Member"
+                        "type": "string"
                     }
                 },
                 "team_name": {
                     "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
-        "users.GetReviewResponse": {
+        "webhooks.AddWebhookRequest": {
             "type": "object",
             "properties": {
-                "pull_requests": {
+                "event_types": {
+                    "description": "EventTypes - ASSIGNED, UNASSIGNED, REASSIGNED, MERGED, CLOSED, REOPENED, REVIEWED; пусто - все события.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/users.PullRequestResponse"
+                        "type": "string"
                     }
                 },
-                "user_id": {
+                "secret": {
+                    "type": "string"
+                },
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "users.PullRequestResponse": {
+        "webhooks.AddWebhookResponse": {
             "type": "object",
             "properties": {
-                "author_id": {
+                "webhook": {
+                    "$ref": "#/definitions/webhooks.WebhookResponse"
+                }
+            }
+        },
+        "webhooks.DeadLetterResponse": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "pull_request_id": {
+                "delivery_id": {
+                    "type": "integer"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "event_type": {
                     "type": "string"
                 },
-                "pull_request_name": {
+                "last_attempt_at": {
                     "type": "string"
                 },
-                "status": {
+                "last_error": {
                     "type": "string"
+                },
+                "payload": {
+                    "description": "Payload - тело, которое не удалось доставить.",
+                    "type": "object"
+                },
+                "url": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "users.SetActiveRequest": {
+        "webhooks.DeadLettersResponse": {
             "type": "object",
             "properties": {
-                "is_active": {
-                    "type": "boolean"
-                },
-                "user_id": {
-                    "type": "string"
+                "dead_letters": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhooks.DeadLetterResponse"
+                    }
                 }
             }
         },
-        "users.SetIsActiveResponse": {
+        "webhooks.DeleteWebhookRequest": {
             "type": "object",
             "properties": {
-                "user": {
-                    "$ref": "#/definitions/users.UserResponse"
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         },
-        "users.UserResponse": {
+        "webhooks.ListWebhooksResponse": {
             "type": "object",
             "properties": {
-                "is_active": {
-                    "type": "boolean"
-                },
-                "team_name": {
+                "webhooks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/webhooks.WebhookResponse"
+                    }
+                }
+            }
+        },
+        "webhooks.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "string"
+                "event_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "username": {
+                "url": {
                     "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
                 }
             }
         }
@@ -3,26 +3,55 @@ package app
 import (
 	"context"
 	"net/http"
+	"pr-reviewer-assigment-service/internal/config"
 	"pr-reviewer-assigment-service/internal/http/router"
+	"pr-reviewer-assigment-service/internal/http/v1/events"
 	"pr-reviewer-assigment-service/internal/http/v1/pull_requests"
 	"pr-reviewer-assigment-service/internal/http/v1/statistics"
 	"pr-reviewer-assigment-service/internal/http/v1/teams"
 	"pr-reviewer-assigment-service/internal/http/v1/users"
+	"pr-reviewer-assigment-service/internal/http/v1/webhooks"
 	"pr-reviewer-assigment-service/internal/repository/postgres"
+	"pr-reviewer-assigment-service/internal/scheduler"
 	"pr-reviewer-assigment-service/internal/service"
+	"pr-reviewer-assigment-service/internal/webhook"
+	"sync"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type App struct {
-	db           *pgxpool.Pool
-	Router       *router.Router
-	UserHandler  *users.UsersHandler
-	TeamHandler  *teams.TeamsHandler
-	PRHandler    *pull_requests.PullRequestHandler
-	StatsHandler *statistics.StatisticsHandler
+	db             *pgxpool.Pool
+	Router         *router.Router
+	UserHandler    *users.UsersHandler
+	TeamHandler    *teams.TeamsHandler
+	PRHandler      *pull_requests.PullRequestHandler
+	StatsHandler   *statistics.StatisticsHandler
+	WebhookHandler *webhooks.WebhookHandler
+	EventsHandler  *events.EventsHandler
+
+	prServ      *service.PullRequestService
+	webhookServ *service.WebhookService
+	listener    *postgres.EventListener
+	// stopBackground останавливает фоновые задачи, запущенные через goBackground.
+	stopBackground []context.CancelFunc
+	background     sync.WaitGroup
 }
 
+// schedulerLockKey - ключ advisory-блокировки, которой экземпляры выбирают лидера планировщика.
+const schedulerLockKey int64 = 0x70725f7265617373
+
+// Значения по умолчанию, если параметры фоновых задач в конфиге не заданы.
+const (
+	defaultSchedulerInterval = 5 * time.Minute
+	defaultWebhookInterval   = 10 * time.Second
+	defaultWebhookBatchSize  = 50
+)
+
+// webhookTimeout ограничивает ожидание ответа подписчика; должен быть меньше аренды одной доставки в WebhookService.
+const webhookTimeout = 10 * time.Second
+
 func NewApp(ctx context.Context, dsn string) (*App, error) {
 	pool, err := pgxpool.New(ctx, dsn)
 	if err != nil {
@@ -34,25 +63,45 @@ func NewApp(ctx context.Context, dsn string) (*App, error) {
 	prRepo := postgres.NewPullRequestRepository(pool)
 	teamRepo := postgres.NewTeamRepository(pool)
 	statsRepo := postgres.NewStatisticsPostgresRepository(pool)
+	eventRepo := postgres.NewAssignmentEventRepository(pool)
+	unavailabilityRepo := postgres.NewUnavailabilityRepository(pool)
+	codeOwnerRepo := postgres.NewCodeOwnerRepository(pool)
+	ruleRepo := postgres.NewReviewerRuleRepository(pool)
+	webhookRepo := postgres.NewWebhookRepository(pool)
+	domainEventRepo := postgres.NewDomainEventRepository(pool)
+	listener := postgres.NewEventListener(pool)
+	transactor := postgres.NewTransactor(pool)
+
+	// события назначений вместе с outbox вебхуков
+	outbox := service.NewWebhookOutbox(eventRepo, webhookRepo)
 
 	// service
-	userServ := service.NewUserService(userRepo)
-	prServ := service.NewPullRequestService(prRepo, userRepo, teamRepo)
-	teamServ := service.NewTeamService(teamRepo, userRepo)
+	prServ := service.NewPullRequestService(prRepo, userRepo, teamRepo, codeOwnerRepo, ruleRepo, outbox, domainEventRepo, transactor)
+	userServ := service.NewUserService(userRepo, unavailabilityRepo, prServ, domainEventRepo, transactor)
+	teamServ := service.NewTeamService(teamRepo, userRepo, codeOwnerRepo, ruleRepo, prServ, domainEventRepo, transactor)
 	statsServ := service.NewStatisticsService(statsRepo)
+	webhookServ := service.NewWebhookService(webhookRepo, webhook.NewHTTPSender(webhookTimeout))
+	eventServ := service.NewEventService(domainEventRepo, listener)
 
 	// handlers
 	userHandler := users.NewUsersHandler(userServ, prServ)
-	teamHandler := teams.NewTeamsHandler(teamServ)
+	teamHandler := teams.NewTeamsHandler(teamServ, prServ)
 	prHandler := pull_requests.NewPullRequestHandler(prServ)
 	statsHandler := statistics.NewStatisticsHandler(statsServ)
+	webhookHandler := webhooks.NewWebhookHandler(webhookServ)
+	eventsHandler := events.NewEventsHandler(eventServ)
 
 	app := &App{
-		db:           pool,
-		UserHandler:  userHandler,
-		TeamHandler:  teamHandler,
-		PRHandler:    prHandler,
-		StatsHandler: statsHandler,
+		db:             pool,
+		UserHandler:    userHandler,
+		TeamHandler:    teamHandler,
+		PRHandler:      prHandler,
+		StatsHandler:   statsHandler,
+		WebhookHandler: webhookHandler,
+		EventsHandler:  eventsHandler,
+		prServ:         prServ,
+		webhookServ:    webhookServ,
+		listener:       listener,
 	}
 
 	app.Router = router.NewRouter()
@@ -68,7 +117,63 @@ func (a *App) Run(addr string) error {
 	return http.ListenAndServe(addr, a.Handler())
 }
 
+// StartScheduler запускает в фоне переназначение зависших ревью, если оно включено в cfg.
+// Планировщик останавливается в Close.
+func (a *App) StartScheduler(ctx context.Context, cfg config.SchedulerConfig) {
+	if !cfg.Enabled {
+		return
+	}
+
+	interval := time.Duration(cfg.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultSchedulerInterval
+	}
+
+	leader := postgres.NewAdvisoryLeader(a.db, schedulerLockKey)
+	a.goBackground(ctx, scheduler.New(leader, a.prServ, interval, cfg.DryRun).Run)
+}
+
+// StartWebhooks запускает в фоне доставку вебхуков из outbox, если она включена в cfg.
+// Воркер останавливается в Close.
+func (a *App) StartWebhooks(ctx context.Context, cfg config.WebhooksConfig) {
+	if !cfg.Enabled {
+		return
+	}
+
+	interval := time.Duration(cfg.IntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultWebhookInterval
+	}
+	batchSize := cfg.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultWebhookBatchSize
+	}
+
+	a.goBackground(ctx, scheduler.NewWebhookWorker(a.webhookServ, interval, batchSize).Run)
+}
+
+// StartEventListener запускает в фоне LISTEN уведомлений о новых событиях, которыми
+// /events будит ожидающие запросы. Без него ожидающие перечитывают outbox раз в секунду.
+func (a *App) StartEventListener(ctx context.Context) {
+	a.goBackground(ctx, a.listener.Run)
+}
+
+// goBackground запускает run в отдельной горутине до вызова Close.
+func (a *App) goBackground(ctx context.Context, run func(ctx context.Context)) {
+	ctx, stop := context.WithCancel(ctx)
+	a.stopBackground = append(a.stopBackground, stop)
+
+	a.background.Go(func() {
+		run(ctx)
+	})
+}
+
 func (a *App) Close() {
+	for _, stop := range a.stopBackground {
+		stop()
+	}
+	a.background.Wait()
+
 	if a.db != nil {
 		a.db.Close()
 	}
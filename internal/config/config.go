@@ -2,10 +2,12 @@ package config
 
 // Config описывает все параметры приложения.
 type Config struct {
-	App      AppConfig      `toml:"app"`
-	HTTP     HTTPConfig     `toml:"http"`
-	Postgres PostgresConfig `toml:"postgres"`
-	Logger   LoggerConfig   `toml:"logger"`
+	App       AppConfig       `toml:"app"`
+	HTTP      HTTPConfig      `toml:"http"`
+	Postgres  PostgresConfig  `toml:"postgres"`
+	Logger    LoggerConfig    `toml:"logger"`
+	Scheduler SchedulerConfig `toml:"scheduler"`
+	Webhooks  WebhooksConfig  `toml:"webhooks"`
 }
 
 // AppConfig общие сведения о приложении (имя, окружение).
@@ -34,3 +36,17 @@ type PostgresConfig struct {
 type LoggerConfig struct {
 	Level string `toml:"level"` // debug / info / warn / error
 }
+
+// SchedulerConfig параметры фонового переназначения зависших ревью.
+type SchedulerConfig struct {
+	Enabled         bool `toml:"enabled"`
+	IntervalSeconds int  `toml:"interval_seconds"` // 300
+	DryRun          bool `toml:"dry_run"`          // только логировать переназначения
+}
+
+// WebhooksConfig параметры доставки вебхуков из outbox.
+type WebhooksConfig struct {
+	Enabled         bool `toml:"enabled"`
+	IntervalSeconds int  `toml:"interval_seconds"` // 10
+	BatchSize       int  `toml:"batch_size"`       // 50
+}
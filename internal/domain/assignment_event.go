@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+// AssignmentEventType - тип записи в истории назначений PR.
+type AssignmentEventType string
+
+const (
+	AssignmentEventAssigned   AssignmentEventType = "ASSIGNED"
+	AssignmentEventUnassigned AssignmentEventType = "UNASSIGNED"
+	AssignmentEventReassigned AssignmentEventType = "REASSIGNED"
+	AssignmentEventMerged     AssignmentEventType = "MERGED"
+	AssignmentEventClosed     AssignmentEventType = "CLOSED"
+	AssignmentEventReopened   AssignmentEventType = "REOPENED"
+	// AssignmentEventReviewed - ревьювер отправил вердикт; вердикт записан в Reason.
+	AssignmentEventReviewed AssignmentEventType = "REVIEWED"
+)
+
+// IsValid сообщает, известен ли тип события сервису.
+func (t AssignmentEventType) IsValid() bool {
+	switch t {
+	case AssignmentEventAssigned, AssignmentEventUnassigned, AssignmentEventReassigned,
+		AssignmentEventMerged, AssignmentEventClosed, AssignmentEventReopened, AssignmentEventReviewed:
+		return true
+	}
+	return false
+}
+
+// Причины событий, которые сервис пишет в историю назначений.
+const (
+	ReasonPRCreated         = "pr_created"
+	ReasonReassignRequested = "reassign_requested"
+	ReasonPRMerged          = "pr_merged"
+	ReasonPRClosed          = "pr_closed"
+	ReasonPRReopened        = "pr_reopened"
+	ReasonReviewerAdded     = "reviewer_added"
+	ReasonReviewerRemoved   = "reviewer_removed"
+)
+
+// AssignmentEvent - запись append-only истории назначений ревьюверов.
+type AssignmentEvent struct {
+	ID            int64
+	PullRequestID string
+	Type          AssignmentEventType
+	// ReviewerID - назначенный (снятый) ревьювер или автор вердикта, для MERGED, CLOSED и REOPENED не заполняется.
+	ReviewerID *string
+	// PreviousReviewerID - кого заменили, заполняется для REASSIGNED.
+	PreviousReviewerID *string
+	// ActorID - кто выполнил операцию, nil для системных действий.
+	ActorID   *string
+	Reason    string
+	CreatedAt time.Time
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// ErrInvalidCodeOwnerRule возвращается, если у правила пустой или некорректный шаблон либо владелец задан не ровно один.
+var ErrInvalidCodeOwnerRule = errors.New("code owner rule must have a valid pattern and exactly one owner")
+
+// CodeOwnerRule - правило владения путями в стиле CODEOWNERS: файлы, подходящие под Pattern,
+// принадлежат пользователю OwnerUserID или команде OwnerTeam (задано ровно одно из двух).
+//
+// Шаблон - glob по сегментам пути: "*" и "?" не выходят за "/", "**" совпадает с любым числом каталогов.
+// Шаблон без "/" (например, "*.sql") ищется в любом каталоге, с "/" - от корня репозитория,
+// "/" в конце шаблона или совпадение с каталогом означает всё его содержимое.
+type CodeOwnerRule struct {
+	Pattern     string
+	OwnerUserID *string
+	OwnerTeam   *string
+}
+
+// Validate проверяет шаблон и владельца правила.
+func (r CodeOwnerRule) Validate() error {
+	if (r.OwnerUserID == nil) == (r.OwnerTeam == nil) {
+		return ErrInvalidCodeOwnerRule
+	}
+	if r.OwnerUserID != nil && *r.OwnerUserID == "" || r.OwnerTeam != nil && *r.OwnerTeam == "" {
+		return ErrInvalidCodeOwnerRule
+	}
+
+	segments := patternSegments(r.Pattern)
+	if len(segments) == 0 {
+		return ErrInvalidCodeOwnerRule
+	}
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return ErrInvalidCodeOwnerRule
+		}
+	}
+	return nil
+}
+
+// Matches сообщает, подпадает ли файл filePath под шаблон правила.
+func (r CodeOwnerRule) Matches(filePath string) bool {
+	pattern := patternSegments(r.Pattern)
+	if len(pattern) == 0 {
+		return false
+	}
+
+	file := splitPath(filePath)
+	// совпадение с каталогом распространяется на всё, что в нём лежит
+	for n := len(file); n > 0; n-- {
+		if matchSegments(pattern, file[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// CodeOwnerReviewer - ревьювер, назначенный как владелец изменённых файлов.
+type CodeOwnerReviewer struct {
+	UserID string
+	// Pattern - шаблон правила, по которому пользователь стал владельцем.
+	Pattern string
+}
+
+// patternSegments разбивает шаблон на сегменты, добавляя "**" в начало неякорных шаблонов и в конец шаблонов каталогов.
+func patternSegments(pattern string) []string {
+	pattern = strings.TrimSpace(pattern)
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+
+	segments := splitPath(pattern)
+	if len(segments) == 0 {
+		return nil
+	}
+	if !anchored {
+		segments = append([]string{"**"}, segments...)
+	}
+	if strings.HasSuffix(pattern, "/") {
+		segments = append(segments, "**")
+	}
+	return segments
+}
+
+func splitPath(p string) []string {
+	var segments []string
+	for _, segment := range strings.Split(p, "/") {
+		if segment != "" && segment != "." {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func matchSegments(pattern, file []string) bool {
+	if len(pattern) == 0 {
+		return len(file) == 0
+	}
+	if pattern[0] == "**" {
+		return matchSegments(pattern[1:], file) || len(file) > 0 && matchSegments(pattern, file[1:])
+	}
+	if len(file) == 0 {
+		return false
+	}
+	ok, err := path.Match(pattern[0], file[0])
+	return err == nil && ok && matchSegments(pattern[1:], file[1:])
+}
@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"pr-reviewer-assigment-service/internal/domain"
+	"testing"
+)
+
+func TestCodeOwnerRuleMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{pattern: "*.sql", path: "migrations/00001_init.up.sql", want: true},
+		{pattern: "*.sql", path: "schema.sql", want: true},
+		{pattern: "*.sql", path: "internal/sql/repo.go", want: false},
+		{pattern: "migrations/", path: "migrations/00001_init.up.sql", want: true},
+		{pattern: "migrations/", path: "db/migrations/00001_init.up.sql", want: true},
+		{pattern: "/migrations", path: "migrations/00001_init.up.sql", want: true},
+		{pattern: "/migrations", path: "db/migrations/00001_init.up.sql", want: false},
+		{pattern: "internal/*/handler.go", path: "internal/http/handler.go", want: true},
+		{pattern: "internal/*/handler.go", path: "internal/http/v1/handler.go", want: false},
+		{pattern: "internal/**/handler.go", path: "internal/http/v1/handler.go", want: true},
+		{pattern: "internal/**/handler.go", path: "internal/handler.go", want: true},
+		{pattern: "docs/**", path: "docs/swagger.yaml", want: true},
+		{pattern: "docs/**", path: "README.md", want: false},
+		{pattern: "", path: "README.md", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.path, func(t *testing.T) {
+			rule := domain.CodeOwnerRule{Pattern: tt.pattern}
+			if got := rule.Matches(tt.path); got != tt.want {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCodeOwnerRuleValidate(t *testing.T) {
+	user := "u1"
+	team := "backend"
+
+	tests := []struct {
+		name    string
+		rule    domain.CodeOwnerRule
+		wantErr bool
+	}{
+		{name: "user owner", rule: domain.CodeOwnerRule{Pattern: "*.go", OwnerUserID: &user}},
+		{name: "team owner", rule: domain.CodeOwnerRule{Pattern: "docs/", OwnerTeam: &team}},
+		{name: "no owner", rule: domain.CodeOwnerRule{Pattern: "*.go"}, wantErr: true},
+		{name: "both owners", rule: domain.CodeOwnerRule{Pattern: "*.go", OwnerUserID: &user, OwnerTeam: &team}, wantErr: true},
+		{name: "empty pattern", rule: domain.CodeOwnerRule{Pattern: " / ", OwnerUserID: &user}, wantErr: true},
+		{name: "bad pattern", rule: domain.CodeOwnerRule{Pattern: "[a-", OwnerUserID: &user}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
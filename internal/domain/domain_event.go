@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// DomainEventType - тип события в потоке изменений для внешних потребителей.
+type DomainEventType string
+
+const (
+	EventPRCreated          DomainEventType = "PR_CREATED"
+	EventReviewerAssigned   DomainEventType = "REVIEWER_ASSIGNED"
+	EventReviewerUnassigned DomainEventType = "REVIEWER_UNASSIGNED"
+	EventPRMerged           DomainEventType = "PR_MERGED"
+	EventPRClosed           DomainEventType = "PR_CLOSED"
+	EventPRReopened         DomainEventType = "PR_REOPENED"
+	EventReviewSubmitted    DomainEventType = "REVIEW_SUBMITTED"
+	EventUserActivated      DomainEventType = "USER_ACTIVATED"
+	EventUserDeactivated    DomainEventType = "USER_DEACTIVATED"
+	// EventTeamMembershipChanged - в команду добавлены или из неё удалены участники.
+	EventTeamMembershipChanged DomainEventType = "TEAM_MEMBERSHIP_CHANGED"
+)
+
+// Ограничения выборки потока событий.
+const (
+	DefaultEventsLimit = 100
+	MaxEventsLimit     = 1000
+	MaxEventsWait      = time.Minute
+)
+
+// EventCursor - позиция в потоке событий: события упорядочены по транзакции, записавшей их, затем по ID.
+// Нулевое значение - начало потока.
+type EventCursor struct {
+	TxID int64
+	ID   int64
+}
+
+// EventLag - отставание потока событий от курсора: события уже закоммичены, но не выдаются,
+// пока не завершится более ранняя открытая транзакция в БД.
+type EventLag struct {
+	// HeldBack - сколько закоммиченных событий после курсора задержано.
+	HeldBack int
+	// Since - когда записано самое раннее задержанное событие, nil - задержанных нет.
+	Since *time.Time
+}
+
+// DomainEvent - запись outbox доменных событий.
+type DomainEvent struct {
+	ID   int64
+	Type DomainEventType
+	// Payload - описание события, JSON.
+	Payload []byte
+	// Cursor - позиция события; передаётся как after, чтобы читать поток дальше.
+	Cursor    EventCursor
+	CreatedAt time.Time
+}
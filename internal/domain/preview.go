@@ -0,0 +1,50 @@
+package domain
+
+// CandidateReason - почему участник команды автора выбран или пропущен при подборе ревьюверов.
+type CandidateReason string
+
+const (
+	// CandidateCodeOwner - выбран как владелец изменённых файлов.
+	CandidateCodeOwner CandidateReason = "CODE_OWNER"
+	// CandidateRequiredByRule - выбран, чтобы выполнить правило REQUIRES_TAG.
+	CandidateRequiredByRule CandidateReason = "REQUIRED_BY_RULE"
+	// CandidateSelected - выбран стратегией команды.
+	CandidateSelected CandidateReason = "SELECTED"
+	// CandidateAuthor - автор PR не ревьюит свой PR.
+	CandidateAuthor CandidateReason = "AUTHOR"
+	// CandidateInactive - пользователь неактивен (is_active = false).
+	CandidateInactive CandidateReason = "INACTIVE"
+	// CandidateUnavailable - у пользователя сейчас период отсутствия.
+	CandidateUnavailable CandidateReason = "UNAVAILABLE"
+	// CandidateExcludedByRule - исключён правилом NEVER_REVIEWS.
+	CandidateExcludedByRule CandidateReason = "EXCLUDED_BY_RULE"
+	// CandidateAtCapacity - исчерпан лимит открытых ревью.
+	CandidateAtCapacity CandidateReason = "AT_CAPACITY"
+	// CandidateNotSelected - подходит, но стратегия предпочла других.
+	CandidateNotSelected CandidateReason = "NOT_SELECTED"
+)
+
+// ReviewerCandidate - участник команды автора с нагрузкой и решением по нему.
+type ReviewerCandidate struct {
+	Member
+	Picked bool
+	Reason CandidateReason
+	// RuleID - правило, из-за которого участник выбран или исключён.
+	RuleID *int64
+	// Pattern - шаблон CODEOWNERS, по которому участник выбран.
+	Pattern string
+}
+
+// AssignmentPreview - результат выбора ревьюверов для будущего PR без записи в БД.
+type AssignmentPreview struct {
+	AuthorID              string
+	TeamName              string
+	AssignedReviewers     []string
+	RequiredReviewers     int
+	InsufficientReviewers bool
+	FallbackReviewers     []FallbackReviewer
+	CodeOwnerReviewers    []CodeOwnerReviewer
+	RuleEffects           []RuleEffect
+	// Candidates - все участники команды автора в порядке команды.
+	Candidates []ReviewerCandidate
+}
@@ -14,9 +14,30 @@ var ErrPRNotFound = errors.New("PR not found")
 // ErrPRMerged возвращается, если PR смержен
 var ErrPRMerged = errors.New("cannot reassign on merged PR")
 
+// ErrPRClosed возвращается при попытке изменить закрытый PR
+var ErrPRClosed = errors.New("pull request is closed")
+
 // ErrIsNotAssigned возвращается, если user не назначен для этого PR
 var ErrIsNotAssigned = errors.New("reviewer is not assigned to this PR")
 
+// ErrAlreadyAssigned возвращается, если user уже назначен ревьювером этого PR
+var ErrAlreadyAssigned = errors.New("reviewer is already assigned to this PR")
+
+// ErrReviewerIsAuthor возвращается при попытке назначить автора ревьювером своего PR
+var ErrReviewerIsAuthor = errors.New("author cannot review own PR")
+
+// ErrReviewerInactive возвращается при попытке вручную назначить неактивного пользователя
+var ErrReviewerInactive = errors.New("reviewer is not active")
+
+// ErrReviewerUnavailable возвращается при попытке вручную назначить пользователя, который сейчас в отпуске
+var ErrReviewerUnavailable = errors.New("reviewer is unavailable")
+
+// ErrReviewerExcluded возвращается, если правило NEVER_REVIEWS запрещает пользователю ревьюить PR автора
+var ErrReviewerExcluded = errors.New("reviewer is excluded by a reviewer rule")
+
+// ErrVersionConflict возвращается, если PR изменился после того, как клиент прочитал его версию
+var ErrVersionConflict = errors.New("pull request version conflict")
+
 // ErrIsNoCandidates возвращается, если нет доступных кандидатов на назначения для PR
 var ErrIsNoCandidates = errors.New("no active replacement candidate in team")
 
@@ -25,13 +46,26 @@ type PRStatus string
 const (
 	PROpenStatus  PRStatus = "OPEN"
 	PRMergeStatus PRStatus = "MERGED"
+	// PRClosedStatus - PR закрыт без merge; может быть переоткрыт.
+	PRClosedStatus PRStatus = "CLOSED"
 )
 
+// IsValid сообщает, известен ли статус сервису.
+func (s PRStatus) IsValid() bool {
+	switch s {
+	case PROpenStatus, PRMergeStatus, PRClosedStatus:
+		return true
+	}
+	return false
+}
+
 type PullRequest struct {
 	PullRequestID   string
 	PullRequestName string
 	AuthorID        string
 	Status          PRStatus
+	// Version увеличивается при каждом изменении ревьюверов или статуса PR.
+	Version int64
 }
 
 type PullRequestAssignment struct {
@@ -39,4 +73,56 @@ type PullRequestAssignment struct {
 	AssignedReviewers []string
 	MergedAt          *time.Time
 	ReplacedBy        *string
+	// RequiredReviewers - сколько ревьюверов требуется PR.
+	RequiredReviewers int
+	// InsufficientReviewers - кандидатов оказалось меньше, чем RequiredReviewers.
+	InsufficientReviewers bool
+	// FallbackReviewers - какие из назначенных ревьюверов взяты из резервных команд.
+	FallbackReviewers []FallbackReviewer
+	// CodeOwnerReviewers - какие из назначенных ревьюверов выбраны как владельцы изменённых файлов.
+	CodeOwnerReviewers []CodeOwnerReviewer
+	// RuleEffects - как правила команды (NEVER_REVIEWS, REQUIRES_TAG) повлияли на выбор ревьюверов.
+	RuleEffects []RuleEffect
+}
+
+// Reviewer - назначенный на PR ревьювер.
+type Reviewer struct {
+	UserID     string
+	AssignedAt time.Time
+	State      ReviewState
+	// ReviewedAt - когда ревьювер отправил последний вердикт, nil для PENDING.
+	ReviewedAt *time.Time
+}
+
+// PullRequestDetails - PR целиком: ревьюверы и временные метки.
+type PullRequestDetails struct {
+	PullRequest
+	RequiredReviewers int
+	// Tags - теги PR (области кода), по ним выбираются ревьюверы с подходящей экспертизой.
+	Tags []string
+	// Reviewers упорядочены по времени назначения.
+	Reviewers []Reviewer
+	CreatedAt time.Time
+	MergedAt  *time.Time
+	ClosedAt  *time.Time
+}
+
+// Approvals возвращает число ревьюверов, одобривших PR.
+func (pr *PullRequestDetails) Approvals() int {
+	approvals := 0
+	for _, reviewer := range pr.Reviewers {
+		if reviewer.State == ReviewApproved {
+			approvals++
+		}
+	}
+	return approvals
+}
+
+// ReviewerIDs возвращает идентификаторы назначенных ревьюверов.
+func (pr *PullRequestDetails) ReviewerIDs() []string {
+	ids := make([]string, 0, len(pr.Reviewers))
+	for _, reviewer := range pr.Reviewers {
+		ids = append(ids, reviewer.UserID)
+	}
+	return ids
 }
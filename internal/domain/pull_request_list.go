@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidListQuery возвращается при неизвестном статусе, поле сортировки или некорректном диапазоне дат.
+var ErrInvalidListQuery = errors.New("invalid pull request list query")
+
+// ErrInvalidCursor возвращается, если курсор повреждён или получен при другой сортировке.
+var ErrInvalidCursor = errors.New("invalid pagination cursor")
+
+// PRSortField - поле сортировки списка PR.
+type PRSortField string
+
+const (
+	// PRSortCreatedAt - по времени создания, при равенстве по id.
+	PRSortCreatedAt PRSortField = "created_at"
+	// PRSortID - по идентификатору PR.
+	PRSortID PRSortField = "pull_request_id"
+)
+
+// IsValid сообщает, поддерживается ли сортировка.
+func (f PRSortField) IsValid() bool {
+	return f == PRSortCreatedAt || f == PRSortID
+}
+
+// Ограничения размера страницы списка PR.
+const (
+	DefaultPRListLimit = 50
+	MaxPRListLimit     = 100
+)
+
+// PullRequestFilter - фильтры списка PR; пустые поля не ограничивают выборку.
+type PullRequestFilter struct {
+	Status     PRStatus
+	AuthorID   string
+	ReviewerID string
+	// TeamName - команда автора PR.
+	TeamName    string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+}
+
+// PullRequestCursor - позиция в списке PR: ключ сортировки последнего элемента страницы.
+type PullRequestCursor struct {
+	Sort      PRSortField
+	Desc      bool
+	CreatedAt time.Time
+	ID        string
+}
+
+// PullRequestListQuery - параметры выборки списка PR.
+type PullRequestListQuery struct {
+	PullRequestFilter
+	Sort   PRSortField
+	Desc   bool
+	Limit  int
+	Cursor *PullRequestCursor
+}
+
+// PullRequestPage - страница списка PR; NextCursor равен nil на последней странице.
+type PullRequestPage struct {
+	Items      []PullRequestDetails
+	Total      int
+	Limit      int
+	NextCursor *PullRequestCursor
+}
@@ -0,0 +1,39 @@
+package domain
+
+// ReasonReviewerDeactivated - причина событий автоматического переназначения при деактивации ревьювера.
+const ReasonReviewerDeactivated = "reviewer_deactivated"
+
+// ReasonReviewStale - причина событий автоматического переназначения ревью, ждущего вердикта
+// дольше порога команды (auto_reassign_hours).
+const ReasonReviewStale = "review_stale"
+
+// ReviewAssignment - назначение пользователя ревьювером PR.
+type ReviewAssignment struct {
+	PullRequestID string
+	UserID        string
+}
+
+// ReassignmentStatus - итог автоматического переназначения одного ревью.
+type ReassignmentStatus string
+
+const (
+	// ReassignmentDone - ревьювер заменён.
+	ReassignmentDone ReassignmentStatus = "REASSIGNED"
+	// ReassignmentNoCandidate - заменить некем, ревьювер остался в PR.
+	ReassignmentNoCandidate ReassignmentStatus = "NO_CANDIDATE"
+	// ReassignmentFailed - переназначение PR не удалось, изменения откатились; причина в Error.
+	ReassignmentFailed ReassignmentStatus = "FAILED"
+)
+
+// ReassignmentOutcome - результат переназначения ревью ReplacedUserID в PR.
+type ReassignmentOutcome struct {
+	PullRequestID  string
+	ReplacedUserID string
+	Status         ReassignmentStatus
+	// NewReviewerID - кто занял место ReplacedUserID, заполняется для ReassignmentDone.
+	NewReviewerID *string
+	// Assignment - состояние PR после замены, заполняется для ReassignmentDone (кроме пробного запуска).
+	Assignment *PullRequestAssignment
+	// Error - причина сбоя, заполняется для ReassignmentFailed.
+	Error string
+}
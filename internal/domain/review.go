@@ -0,0 +1,38 @@
+package domain
+
+import "errors"
+
+// ErrInvalidReviewState возвращается, если вердикт ревью неизвестен или не может быть отправлен.
+var ErrInvalidReviewState = errors.New("review state must be APPROVED, CHANGES_REQUESTED or DISMISSED")
+
+// ErrInvalidRequiredApprovals возвращается, если required_approvals вне диапазона 0..MaxReviewers.
+var ErrInvalidRequiredApprovals = errors.New("required approvals is out of range")
+
+// ErrNotApproved возвращается при merge PR, у которого одобрений меньше, чем требует команда автора.
+var ErrNotApproved = errors.New("pull request does not have enough approvals")
+
+// ErrNotReviewer возвращается, если вердикт отправляет не сам ревьювер (X-Actor-ID не совпадает с reviewer_id).
+var ErrNotReviewer = errors.New("review can be submitted only by the reviewer")
+
+// ReviewState - состояние ревью конкретного ревьювера PR.
+type ReviewState string
+
+const (
+	// ReviewPending - ревьювер назначен, но ещё не отправил вердикт.
+	ReviewPending ReviewState = "PENDING"
+	// ReviewApproved - ревьювер одобрил PR.
+	ReviewApproved ReviewState = "APPROVED"
+	// ReviewChangesRequested - ревьювер запросил изменения.
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+	// ReviewDismissed - прежний вердикт отозван и не учитывается.
+	ReviewDismissed ReviewState = "DISMISSED"
+)
+
+// IsVerdict сообщает, можно ли отправить состояние через /pullRequest/review.
+func (s ReviewState) IsVerdict() bool {
+	switch s {
+	case ReviewApproved, ReviewChangesRequested, ReviewDismissed:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidReviewSLA возвращается, если review_sla_hours отрицательный.
+var ErrInvalidReviewSLA = errors.New("review SLA hours must not be negative")
+
+// ErrInvalidAutoReassign возвращается, если auto_reassign_hours отрицательный.
+var ErrInvalidAutoReassign = errors.New("auto reassign hours must not be negative")
+
+// ErrInvalidBusinessHours возвращается, если рабочий день команды задан неверно: часы вне 0..24,
+// начало не раньше конца или неизвестный часовой пояс.
+var ErrInvalidBusinessHours = errors.New("business day must be within 0..24 hours, start before end, in a known time zone")
+
+// Рабочий день команды по умолчанию: будни с 09:00 до 18:00 UTC.
+const (
+	DefaultBusinessDayStart = 9
+	DefaultBusinessDayEnd   = 18
+	DefaultBusinessTimeZone = "UTC"
+)
+
+// BusinessHours - рабочее время команды, по которому считаются SLA ревью и автоматическое переназначение:
+// будни с StartHour до EndHour по местному времени Location.
+type BusinessHours struct {
+	StartHour int
+	EndHour   int
+	Location  *time.Location
+}
+
+// DefaultBusinessHours - рабочее время команды, не задавшей своё.
+var DefaultBusinessHours = BusinessHours{
+	StartHour: DefaultBusinessDayStart,
+	EndHour:   DefaultBusinessDayEnd,
+	Location:  time.UTC,
+}
+
+// NewBusinessHours проверяет рабочий день команды и загружает её часовой пояс (IANA, например Europe/Moscow).
+func NewBusinessHours(startHour, endHour int, timeZone string) (BusinessHours, error) {
+	if startHour < 0 || endHour > 24 || startHour >= endHour {
+		return BusinessHours{}, ErrInvalidBusinessHours
+	}
+	location, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return BusinessHours{}, fmt.Errorf("%w: %v", ErrInvalidBusinessHours, err)
+	}
+	return BusinessHours{StartHour: startHour, EndHour: endHour, Location: location}, nil
+}
+
+// AddBusinessHours возвращает момент (в UTC), когда после from пройдёт hours рабочих часов по calendar.
+// Выходные и время вне рабочего дня не учитываются; нулевой calendar - DefaultBusinessHours.
+func AddBusinessHours(from time.Time, hours int, calendar BusinessHours) time.Time {
+	if calendar.Location == nil {
+		calendar = DefaultBusinessHours
+	}
+	t := from.In(calendar.Location)
+	remaining := time.Duration(hours) * time.Hour
+
+	for {
+		// границы дня считаются по местным часам, поэтому переход на летнее время их не сдвигает
+		year, month, date := t.Date()
+		day := time.Date(year, month, date, 0, 0, 0, 0, calendar.Location)
+		start := time.Date(year, month, date, calendar.StartHour, 0, 0, 0, calendar.Location)
+		end := time.Date(year, month, date, calendar.EndHour, 0, 0, 0, calendar.Location)
+		next := time.Date(year, month, date+1, 0, 0, 0, 0, calendar.Location)
+
+		if isWeekend(day) || !t.Before(end) {
+			t = next
+			continue
+		}
+		if t.Before(start) {
+			t = start
+		}
+
+		if left := end.Sub(t); remaining <= left {
+			return t.Add(remaining).UTC()
+		}
+		remaining -= end.Sub(t)
+		t = next
+	}
+}
+
+func isWeekend(day time.Time) bool {
+	return day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+}
+
+// AssignedReview - назначение ревьювера в PR с данными для SLA.
+type AssignedReview struct {
+	PullRequest
+	ReviewerID string
+	AssignedAt time.Time
+	State      ReviewState
+	// SLAHours - SLA команды ревьювера в рабочих часах, nil - SLA не задан.
+	SLAHours *int
+	// DueAt - крайний срок ревью, заполняется ApplySLA, если SLA задан.
+	DueAt *time.Time
+	// Overdue - PR открыт, вердикта нет, а срок уже прошёл.
+	Overdue bool
+	// AutoReassignHours - порог автоматического переназначения команды ревьювера, nil - не задан.
+	AutoReassignHours *int
+	// BusinessHours - рабочее время команды ревьювера, по которому считаются SLA и порог.
+	BusinessHours BusinessHours
+}
+
+// ApplySLA рассчитывает срок ревью и просроченность на момент now.
+func (r *AssignedReview) ApplySLA(now time.Time) {
+	if r.SLAHours == nil {
+		return
+	}
+	dueAt := AddBusinessHours(r.AssignedAt, *r.SLAHours, r.BusinessHours)
+	r.DueAt = &dueAt
+	r.Overdue = r.Status == PROpenStatus && r.State == ReviewPending && now.After(dueAt)
+}
+
+// IsStale сообщает, что ревью в открытом PR ждёт вердикта дольше порога автоматического переназначения.
+func (r *AssignedReview) IsStale(now time.Time) bool {
+	if r.AutoReassignHours == nil || r.Status != PROpenStatus || r.State != ReviewPending {
+		return false
+	}
+	return now.After(AddBusinessHours(r.AssignedAt, *r.AutoReassignHours, r.BusinessHours))
+}
@@ -0,0 +1,135 @@
+package domain_test
+
+import (
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestAddBusinessHours(t *testing.T) {
+	// 2025-11-14 - пятница
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2025, 11, 14, hour, minute, 0, 0, time.UTC)
+	}
+
+	moscow, err := domain.NewBusinessHours(10, 19, "Europe/Moscow")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		hours    int
+		calendar domain.BusinessHours
+		want     time.Time
+	}{
+		{name: "same day", from: friday(10, 0), hours: 3, want: friday(13, 0)},
+		{name: "ends at close", from: friday(10, 0), hours: 8, want: friday(18, 0)},
+		{name: "over weekend", from: friday(16, 30), hours: 3, want: time.Date(2025, 11, 17, 10, 30, 0, 0, time.UTC)},
+		{name: "before start", from: friday(7, 0), hours: 1, want: friday(10, 0)},
+		{name: "after close", from: friday(20, 0), hours: 1, want: time.Date(2025, 11, 17, 10, 0, 0, 0, time.UTC)},
+		{name: "from weekend", from: time.Date(2025, 11, 15, 12, 0, 0, 0, time.UTC), hours: 2, want: time.Date(2025, 11, 17, 11, 0, 0, 0, time.UTC)},
+		{name: "24 business hours", from: friday(9, 0), hours: 24, want: time.Date(2025, 11, 18, 15, 0, 0, 0, time.UTC)},
+		{name: "other timezone", from: friday(10, 0).In(time.FixedZone("MSK", 3*3600)), hours: 1, want: friday(11, 0)},
+		// 10:00 UTC - 13:00 по Москве
+		{name: "team time zone", from: friday(10, 0), hours: 3, calendar: moscow, want: friday(13, 0)},
+		// 15:00 UTC - 18:00 по Москве: час до конца дня, остаток с 10:00 понедельника по Москве
+		{name: "team day ends", from: friday(15, 0), hours: 2, calendar: moscow, want: time.Date(2025, 11, 17, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := domain.AddBusinessHours(tt.from, tt.hours, tt.calendar); !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewBusinessHours(t *testing.T) {
+	tests := []struct {
+		name     string
+		start    int
+		end      int
+		timeZone string
+	}{
+		{name: "start after end", start: 18, end: 9, timeZone: "UTC"},
+		{name: "hour out of range", start: 9, end: 25, timeZone: "UTC"},
+		{name: "unknown time zone", start: 9, end: 18, timeZone: "Mars/Olympus"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := domain.NewBusinessHours(tt.start, tt.end, tt.timeZone); !errors.Is(err, domain.ErrInvalidBusinessHours) {
+				t.Fatalf("expected ErrInvalidBusinessHours, got %v", err)
+			}
+		})
+	}
+}
+
+func TestAssignedReviewApplySLA(t *testing.T) {
+	sla := 2
+	assignedAt := time.Date(2025, 11, 17, 9, 0, 0, 0, time.UTC)
+	now := assignedAt.Add(3 * time.Hour)
+
+	tests := []struct {
+		name   string
+		review domain.AssignedReview
+		want   bool
+	}{
+		{name: "pending past deadline", review: domain.AssignedReview{State: domain.ReviewPending}, want: true},
+		{name: "approved", review: domain.AssignedReview{State: domain.ReviewApproved}, want: false},
+		{name: "merged PR", review: domain.AssignedReview{State: domain.ReviewPending, PullRequest: domain.PullRequest{Status: domain.PRMergeStatus}}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review := tt.review
+			if review.Status == "" {
+				review.Status = domain.PROpenStatus
+			}
+			review.AssignedAt = assignedAt
+			review.SLAHours = &sla
+			review.ApplySLA(now)
+			if review.Overdue != tt.want {
+				t.Fatalf("expected overdue %v, got %v", tt.want, review.Overdue)
+			}
+		})
+	}
+
+	review := domain.AssignedReview{AssignedAt: assignedAt, State: domain.ReviewPending}
+	review.ApplySLA(now)
+	if review.Overdue || review.DueAt != nil {
+		t.Fatal("review without SLA must not be overdue")
+	}
+}
+
+func TestAssignedReviewIsStale(t *testing.T) {
+	// пятница 09:00, порог 16 рабочих часов истекает в понедельник в 16:00
+	assignedAt := time.Date(2025, 11, 14, 9, 0, 0, 0, time.UTC)
+	threshold := 16
+
+	review := domain.AssignedReview{
+		PullRequest: domain.PullRequest{Status: domain.PROpenStatus},
+		AssignedAt:  assignedAt,
+		State:       domain.ReviewPending,
+	}
+	if review.IsStale(assignedAt.AddDate(0, 1, 0)) {
+		t.Fatal("review without threshold must not be stale")
+	}
+
+	review.AutoReassignHours = &threshold
+	if review.IsStale(time.Date(2025, 11, 17, 15, 0, 0, 0, time.UTC)) {
+		t.Fatal("review must not be stale before threshold")
+	}
+	if !review.IsStale(time.Date(2025, 11, 17, 17, 0, 0, 0, time.UTC)) {
+		t.Fatal("review must be stale after threshold")
+	}
+
+	review.State = domain.ReviewChangesRequested
+	if review.IsStale(time.Date(2025, 11, 17, 17, 0, 0, 0, time.UTC)) {
+		t.Fatal("review with verdict must not be stale")
+	}
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidReviewerRule возвращается, если тип правила неизвестен или его поля не соответствуют типу.
+var ErrInvalidReviewerRule = errors.New("invalid reviewer rule")
+
+// ErrReviewerRuleNotFound возвращается, если правила с таким ID у команды нет.
+var ErrReviewerRuleNotFound = errors.New("reviewer rule not found")
+
+// ReviewerRuleType - тип правила подбора ревьюверов для автора.
+type ReviewerRuleType string
+
+const (
+	// RuleNeverReviews - ReviewerID никогда не ревьюит PR автора AuthorID.
+	RuleNeverReviews ReviewerRuleType = "NEVER_REVIEWS"
+	// RuleRequiresTag - у PR автора AuthorID должен быть хотя бы один ревьювер с тегом Tag (например, senior).
+	RuleRequiresTag ReviewerRuleType = "REQUIRES_TAG"
+)
+
+// ReviewerRule - правило команды, которое применяется к PR автора до балансировки нагрузки.
+type ReviewerRule struct {
+	ID       int64
+	TeamName string
+	Type     ReviewerRuleType
+	AuthorID string
+	// ReviewerID заполняется для NEVER_REVIEWS.
+	ReviewerID *string
+	// Tag заполняется для REQUIRES_TAG.
+	Tag       *string
+	CreatedAt time.Time
+}
+
+// Validate проверяет, что заданы ровно те поля, которые нужны типу правила.
+func (r ReviewerRule) Validate() error {
+	if r.AuthorID == "" {
+		return ErrInvalidReviewerRule
+	}
+
+	switch r.Type {
+	case RuleNeverReviews:
+		if r.ReviewerID == nil || *r.ReviewerID == "" || *r.ReviewerID == r.AuthorID || r.Tag != nil {
+			return ErrInvalidReviewerRule
+		}
+	case RuleRequiresTag:
+		if r.Tag == nil || r.ReviewerID != nil {
+			return ErrInvalidReviewerRule
+		}
+	default:
+		return ErrInvalidReviewerRule
+	}
+	return nil
+}
+
+// RuleEffectKind - как правило повлияло на выбор ревьюверов.
+type RuleEffectKind string
+
+const (
+	// RuleEffectExcluded - пользователь не рассматривался из-за NEVER_REVIEWS.
+	RuleEffectExcluded RuleEffectKind = "EXCLUDED"
+	// RuleEffectRequired - пользователь выбран, чтобы выполнить REQUIRES_TAG.
+	RuleEffectRequired RuleEffectKind = "REQUIRED"
+	// RuleEffectSatisfied - REQUIRES_TAG уже выполнено ревьювером, назначенным раньше.
+	RuleEffectSatisfied RuleEffectKind = "SATISFIED"
+	// RuleEffectUnsatisfied - кандидата с нужным тегом не нашлось.
+	RuleEffectUnsatisfied RuleEffectKind = "UNSATISFIED"
+)
+
+// RuleEffect - влияние правила на назначение; UserID пуст для UNSATISFIED.
+type RuleEffect struct {
+	RuleID int64
+	Type   ReviewerRuleType
+	UserID string
+	Effect RuleEffectKind
+}
@@ -1,6 +1,10 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"slices"
+	"time"
+)
 
 // ErrTeamNotFound возвращается, если команда с указанным именем не найдена.
 var ErrTeamNotFound = errors.New("team not found")
@@ -12,14 +16,196 @@ var ErrTeamAlreadyExists = errors.New("team_name already exists")
 // ErrUserAlreadyInTeam возвращается, если пользователь уже состоит в команде.
 var ErrUserAlreadyInTeam = errors.New("user already in team")
 
+// ErrUserNotInTeam возвращается, если пользователь не состоит в указанной команде.
+var ErrUserNotInTeam = errors.New("user is not a member of the team")
+
+// ErrUnknownSelectionStrategy возвращается, если для команды указана неизвестная стратегия выбора ревьюверов.
+var ErrUnknownSelectionStrategy = errors.New("unknown reviewer selection strategy")
+
+// ErrUnknownLoadMetric возвращается, если для команды указана неизвестная метрика нагрузки
+// или неположительный параметр метрики.
+var ErrUnknownLoadMetric = errors.New("unknown reviewer load metric")
+
+// ErrInvalidReviewersCount возвращается, если требуемое число ревьюверов вне диапазона [1, MaxReviewers].
+var ErrInvalidReviewersCount = errors.New("reviewers count is out of range")
+
+// ErrInvalidFallbackTeam возвращается, если команда указана резервной для самой себя.
+var ErrInvalidFallbackTeam = errors.New("team cannot be its own fallback")
+
+// SelectionStrategy определяет, как из кандидатов команды выбираются ревьюверы.
+type SelectionStrategy string
+
+const (
+	// StrategyLeastLoaded - кандидаты с наименьшей нагрузкой по метрике команды (LoadMetric).
+	StrategyLeastLoaded SelectionStrategy = "LEAST_LOADED"
+	// StrategyRoundRobin - по кругу: дольше всех не получавшие ревью идут первыми.
+	StrategyRoundRobin SelectionStrategy = "ROUND_ROBIN"
+	// StrategyWeightedRandom - случайный выбор, вес обратно пропорционален нагрузке.
+	StrategyWeightedRandom SelectionStrategy = "WEIGHTED_RANDOM"
+	// StrategyLeastOpenReviews - кандидаты с наименьшим числом открытых ревью.
+	StrategyLeastOpenReviews SelectionStrategy = "LEAST_OPEN_REVIEWS"
+)
+
+// DefaultSelectionStrategy используется, если стратегия команды не задана.
+const DefaultSelectionStrategy = StrategyLeastLoaded
+
+// IsValid сообщает, известна ли стратегия сервису.
+func (s SelectionStrategy) IsValid() bool {
+	switch s {
+	case StrategyLeastLoaded, StrategyRoundRobin, StrategyWeightedRandom, StrategyLeastOpenReviews:
+		return true
+	}
+	return false
+}
+
+// LoadMetric определяет, как считается нагрузка ревьювера при выборе кандидатов.
+type LoadMetric string
+
+const (
+	// LoadMetricAllTime - все назначения за всё время.
+	LoadMetricAllTime LoadMetric = "ALL_TIME"
+	// LoadMetricOpen - только назначения в открытых PR.
+	LoadMetricOpen LoadMetric = "OPEN"
+	// LoadMetricWindow - назначения за последние LoadWindowHours часов.
+	LoadMetricWindow LoadMetric = "WINDOW"
+	// LoadMetricDecay - назначения с экспоненциальным затуханием, вес уменьшается вдвое каждые LoadHalfLifeHours часов.
+	LoadMetricDecay LoadMetric = "DECAY"
+)
+
+// Значения настроек команды по умолчанию.
+const (
+	DefaultRequiredReviewers = 2
+	// MaxReviewers - верхняя граница числа ревьюверов на PR.
+	MaxReviewers = 10
+
+	DefaultLoadMetric        = LoadMetricOpen
+	DefaultLoadWindowHours   = 720
+	DefaultLoadHalfLifeHours = 168
+)
+
+// IsValid сообщает, известна ли метрика сервису.
+func (m LoadMetric) IsValid() bool {
+	switch m {
+	case LoadMetricAllTime, LoadMetricOpen, LoadMetricWindow, LoadMetricDecay:
+		return true
+	}
+	return false
+}
+
 type Member struct {
-	UserID    string
-	Username  string
-	IsActive  bool
-	PRReviews *int64
+	UserID         string
+	Username       string
+	IsActive       bool
+	PRReviews      *int64
+	OpenReviews    *int64
+	LastAssignedAt *time.Time
+	// Load - нагрузка по метрике команды (LoadMetric).
+	Load *float64
+	// MaxOpenReviews - лимит открытых ревью участника, nil - без ограничения.
+	MaxOpenReviews *int
+	// Tags - области экспертизы участника.
+	Tags []string
+}
+
+// HasAnyTag сообщает, есть ли у участника хотя бы один из тегов tags.
+func (m Member) HasAnyTag(tags []string) bool {
+	for _, tag := range tags {
+		if slices.Contains(m.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// AtCapacity сообщает, что у участника уже столько открытых ревью, сколько он может вести.
+func (m Member) AtCapacity() bool {
+	if m.MaxOpenReviews == nil {
+		return false
+	}
+	var open int64
+	if m.OpenReviews != nil {
+		open = *m.OpenReviews
+	}
+	return open >= int64(*m.MaxOpenReviews)
+}
+
+// TeamSettings - настройки команды, влияющие на назначение ревьюверов.
+type TeamSettings struct {
+	RequiredReviewers int
+	SelectionStrategy SelectionStrategy
+	LoadMetric        LoadMetric
+	LoadWindowHours   int
+	LoadHalfLifeHours int
+	// FallbackTeams - резервные команды в порядке приоритета: из них добираются ревьюверы,
+	// если в команде не хватило кандидатов.
+	FallbackTeams []string
+	// RequiredApprovals - сколько одобрений нужно PR автора команды для merge, 0 - без проверки.
+	// При обновлении команды nil оставляет текущее значение.
+	RequiredApprovals *int
+	// ReviewSLAHours - за сколько рабочих часов участники команды должны отправить вердикт, nil - SLA нет.
+	// При обновлении команды nil оставляет текущее значение, 0 снимает SLA.
+	ReviewSLAHours *int
+	// AutoReassignHours - через сколько рабочих часов без вердикта ревью участника команды
+	// автоматически переназначается, nil - не переназначается. При обновлении nil оставляет
+	// текущее значение, 0 отключает переназначение.
+	AutoReassignHours *int
+	// BusinessDayStart, BusinessDayEnd - часы начала и конца рабочего дня команды (0..24),
+	// BusinessTimeZone - её часовой пояс IANA. По ним считаются SLA ревью и auto_reassign_hours.
+	// При обновлении команды nil и пустая строка оставляют текущее значение.
+	BusinessDayStart *int
+	BusinessDayEnd   *int
+	BusinessTimeZone string
+}
+
+// WithDefaults подставляет значения по умолчанию вместо незаданных настроек.
+func (s TeamSettings) WithDefaults() TeamSettings {
+	if s.RequiredReviewers == 0 {
+		s.RequiredReviewers = DefaultRequiredReviewers
+	}
+	if s.SelectionStrategy == "" {
+		s.SelectionStrategy = DefaultSelectionStrategy
+	}
+	if s.LoadMetric == "" {
+		s.LoadMetric = DefaultLoadMetric
+	}
+	if s.LoadWindowHours == 0 {
+		s.LoadWindowHours = DefaultLoadWindowHours
+	}
+	if s.LoadHalfLifeHours == 0 {
+		s.LoadHalfLifeHours = DefaultLoadHalfLifeHours
+	}
+	if s.RequiredApprovals == nil {
+		noApprovals := 0
+		s.RequiredApprovals = &noApprovals
+	}
+	if s.BusinessDayStart == nil {
+		start := DefaultBusinessDayStart
+		s.BusinessDayStart = &start
+	}
+	if s.BusinessDayEnd == nil {
+		end := DefaultBusinessDayEnd
+		s.BusinessDayEnd = &end
+	}
+	if s.BusinessTimeZone == "" {
+		s.BusinessTimeZone = DefaultBusinessTimeZone
+	}
+	return s
+}
+
+// BusinessHours возвращает рабочее время команды; незаданные поля берутся по умолчанию.
+func (s TeamSettings) BusinessHours() (BusinessHours, error) {
+	s = s.WithDefaults()
+	return NewBusinessHours(*s.BusinessDayStart, *s.BusinessDayEnd, s.BusinessTimeZone)
+}
+
+// FallbackReviewer - ревьювер, выбранный из резервной команды.
+type FallbackReviewer struct {
+	UserID   string
+	TeamName string
 }
 
 type Team struct {
 	TeamName string
 	Members  []Member
+	TeamSettings
 }
@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrInvalidUnavailability возвращается, если период отсутствия пустой или заканчивается раньше, чем начинается.
+var ErrInvalidUnavailability = errors.New("unavailability must end after it starts")
+
+// ErrUnavailabilityNotFound возвращается, если у пользователя нет периода отсутствия с указанным ID.
+var ErrUnavailabilityNotFound = errors.New("unavailability not found")
+
+// Unavailability - запланированный период отсутствия пользователя [StartsAt, EndsAt).
+// Пока период покрывает текущий момент, пользователь не выбирается ревьювером.
+type Unavailability struct {
+	ID        int64
+	UserID    string
+	StartsAt  time.Time
+	EndsAt    time.Time
+	Reason    string
+	CreatedAt time.Time
+}
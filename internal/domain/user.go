@@ -1,13 +1,46 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+)
 
 // ErrUserNotFound возвращается, если пользователь с указанным идентификатором отсутствует в системе.
 var ErrUserNotFound = errors.New("user not found")
 
+// ErrInvalidCapacity возвращается, если max_open_reviews отрицательный.
+var ErrInvalidCapacity = errors.New("max_open_reviews must not be negative")
+
+// ErrInvalidTag возвращается для пустого тега или тега с пробельными символами.
+var ErrInvalidTag = errors.New("tag must be a non-empty word without spaces")
+
 type User struct {
 	ID       string
 	Username string
 	TeamName *string
 	IsActive bool
+	// MaxOpenReviews - сколько открытых ревью пользователь может вести одновременно, nil - без ограничения.
+	MaxOpenReviews *int
+	// Tags - области экспертизы пользователя (go, sql, frontend), в нижнем регистре.
+	Tags []string
+}
+
+// NormalizeTags приводит теги к нижнему регистру, убирает повторы и сортирует. nil остаётся nil.
+func NormalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || strings.IndexFunc(tag, unicode.IsSpace) >= 0 {
+			return nil, ErrInvalidTag
+		}
+		normalized = append(normalized, tag)
+	}
+	slices.Sort(normalized)
+	return slices.Compact(normalized), nil
 }
@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"slices"
+	"time"
+)
+
+// ErrInvalidWebhook возвращается, если у подписки некорректный URL, пустой секрет или неизвестный тип события.
+var ErrInvalidWebhook = errors.New("invalid webhook subscription")
+
+// ErrWebhookNotFound возвращается, если подписки с таким ID нет.
+var ErrWebhookNotFound = errors.New("webhook subscription not found")
+
+// Доставка вебхука повторяется с экспоненциальной задержкой, пока число попыток не достигнет WebhookMaxAttempts.
+const (
+	WebhookMaxAttempts = 8
+	WebhookBaseBackoff = 30 * time.Second
+	WebhookMaxBackoff  = time.Hour
+)
+
+// WebhookSignaturePrefix - префикс подписи в заголовке X-Webhook-Signature.
+const WebhookSignaturePrefix = "sha256="
+
+// WebhookSubscription - подписка внешнего сервиса на события назначений.
+type WebhookSubscription struct {
+	ID     int64
+	URL    string
+	Secret string
+	// EventTypes - на какие события подписка; пустой список - на все.
+	EventTypes []AssignmentEventType
+	CreatedAt  time.Time
+}
+
+// Validate проверяет URL (http или https с хостом), секрет и типы событий.
+func (s WebhookSubscription) Validate() error {
+	u, err := url.Parse(s.URL)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return ErrInvalidWebhook
+	}
+	if s.Secret == "" {
+		return ErrInvalidWebhook
+	}
+	for _, eventType := range s.EventTypes {
+		if !eventType.IsValid() {
+			return ErrInvalidWebhook
+		}
+	}
+	return nil
+}
+
+// Matches сообщает, нужно ли отправлять подписке событие eventType.
+func (s WebhookSubscription) Matches(eventType AssignmentEventType) bool {
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, eventType)
+}
+
+// WebhookDeliveryStatus - состояние доставки события подписчику.
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookPending - доставка ждёт очередной попытки.
+	WebhookPending WebhookDeliveryStatus = "PENDING"
+	// WebhookDelivered - подписчик ответил 2xx.
+	WebhookDelivered WebhookDeliveryStatus = "DELIVERED"
+	// WebhookDead - попытки исчерпаны, доставка попала в dead letters.
+	WebhookDead WebhookDeliveryStatus = "DEAD"
+)
+
+// WebhookDelivery - запись outbox: событие, которое нужно доставить подписчику.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	// URL и Secret подписки заполняются при выборке на доставку.
+	URL       string
+	Secret    string
+	EventID   int64
+	EventType AssignmentEventType
+	// Payload - тело запроса, JSON.
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	LastError     *string
+	CreatedAt     time.Time
+	DeliveredAt   *time.Time
+}
+
+// RecordSuccess отмечает доставку успешной.
+func (d *WebhookDelivery) RecordSuccess(now time.Time) {
+	d.Attempts++
+	d.Status = WebhookDelivered
+	d.LastAttemptAt = &now
+	d.DeliveredAt = &now
+	d.LastError = nil
+}
+
+// RecordFailure учитывает неудачную попытку: назначает следующую через WebhookBackoff
+// или переводит доставку в WebhookDead, если попытки исчерпаны.
+func (d *WebhookDelivery) RecordFailure(now time.Time, reason string) {
+	d.Attempts++
+	d.LastAttemptAt = &now
+	d.LastError = &reason
+	if d.Attempts >= WebhookMaxAttempts {
+		d.Status = WebhookDead
+		return
+	}
+	d.Status = WebhookPending
+	d.NextAttemptAt = now.Add(WebhookBackoff(d.Attempts))
+}
+
+// WebhookBackoff возвращает задержку перед попыткой после attempts неудачных:
+// WebhookBaseBackoff, удваиваясь с каждой попыткой, но не больше WebhookMaxBackoff.
+func WebhookBackoff(attempts int) time.Duration {
+	backoff := WebhookBaseBackoff
+	for i := 1; i < attempts && backoff < WebhookMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, WebhookMaxBackoff)
+}
+
+// SignWebhookPayload возвращает подпись тела запроса: HMAC-SHA256 с секретом подписки в hex с префиксом sha256=.
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return WebhookSignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,84 @@
+package domain_test
+
+import (
+	"pr-reviewer-assigment-service/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestWebhookBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 50, want: time.Hour},
+	}
+
+	for _, tt := range tests {
+		if got := domain.WebhookBackoff(tt.attempts); got != tt.want {
+			t.Errorf("WebhookBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestWebhookDeliveryRecordFailure(t *testing.T) {
+	now := time.Date(2025, 11, 17, 9, 0, 0, 0, time.UTC)
+	delivery := domain.WebhookDelivery{Status: domain.WebhookPending}
+
+	delivery.RecordFailure(now, "unexpected status 500")
+	if delivery.Status != domain.WebhookPending || delivery.Attempts != 1 {
+		t.Fatalf("expected pending after first failure, got %s with %d attempts", delivery.Status, delivery.Attempts)
+	}
+	if want := now.Add(30 * time.Second); !delivery.NextAttemptAt.Equal(want) {
+		t.Fatalf("expected next attempt at %v, got %v", want, delivery.NextAttemptAt)
+	}
+
+	for delivery.Attempts < domain.WebhookMaxAttempts {
+		delivery.RecordFailure(now, "unexpected status 500")
+	}
+	if delivery.Status != domain.WebhookDead {
+		t.Fatalf("expected dead after %d attempts, got %s", domain.WebhookMaxAttempts, delivery.Status)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// эталон: echo -n '{"event_id":1}' | openssl dgst -sha256 -hmac secret
+	got := domain.SignWebhookPayload("secret", []byte(`{"event_id":1}`))
+	want := "sha256=3d3dbd3accc99a0bb4f17b2a1d568db0309e185df63db0dfa698523872a3ccbe"
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestWebhookSubscription(t *testing.T) {
+	valid := domain.WebhookSubscription{URL: "https://chat.example.com/hook", Secret: "s"}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !valid.Matches(domain.AssignmentEventMerged) {
+		t.Fatal("subscription without event types must match every event")
+	}
+
+	filtered := valid
+	filtered.EventTypes = []domain.AssignmentEventType{domain.AssignmentEventAssigned}
+	if filtered.Matches(domain.AssignmentEventMerged) || !filtered.Matches(domain.AssignmentEventAssigned) {
+		t.Fatal("subscription must match only listed event types")
+	}
+
+	invalid := []domain.WebhookSubscription{
+		{URL: "ftp://chat.example.com", Secret: "s"},
+		{URL: "https://", Secret: "s"},
+		{URL: "https://chat.example.com"},
+		{URL: "https://chat.example.com", Secret: "s", EventTypes: []domain.AssignmentEventType{"PUSHED"}},
+	}
+	for _, subscription := range invalid {
+		if err := subscription.Validate(); err == nil {
+			t.Errorf("expected ErrInvalidWebhook for %+v", subscription)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"net/http"
+	"pr-reviewer-assigment-service/internal/service"
+)
+
+// ActorHeader - заголовок с идентификатором пользователя, выполняющего запрос.
+// Значение попадает в историю назначений как actor_id.
+const ActorHeader = "X-Actor-ID"
+
+// withActor переносит ActorHeader в контекст запроса для сервисного слоя.
+func withActor(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if actorID := r.Header.Get(ActorHeader); actorID != "" {
+			r = r.WithContext(service.WithActor(r.Context(), actorID))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
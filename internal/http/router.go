@@ -3,20 +3,24 @@ package http
 import (
 	"net/http"
 	"pr-reviewer-assigment-service/internal/http/router"
+	"pr-reviewer-assigment-service/internal/http/v1/events"
 	"pr-reviewer-assigment-service/internal/http/v1/pull_requests"
 	"pr-reviewer-assigment-service/internal/http/v1/statistics"
 	"pr-reviewer-assigment-service/internal/http/v1/teams"
 	"pr-reviewer-assigment-service/internal/http/v1/users"
+	"pr-reviewer-assigment-service/internal/http/v1/webhooks"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 type RoutesHandlers struct {
-	Router       *router.Router
-	UserHandler  *users.UsersHandler
-	TeamHandler  *teams.TeamsHandler
-	PrHandler    *pull_requests.PullRequestHandler
-	StatsHandler *statistics.StatisticsHandler
+	Router         *router.Router
+	UserHandler    *users.UsersHandler
+	TeamHandler    *teams.TeamsHandler
+	PrHandler      *pull_requests.PullRequestHandler
+	StatsHandler   *statistics.StatisticsHandler
+	WebhookHandler *webhooks.WebhookHandler
+	EventsHandler  *events.EventsHandler
 }
 
 func RegisterRoutes(h RoutesHandlers) http.Handler {
@@ -25,28 +29,59 @@ func RegisterRoutes(h RoutesHandlers) http.Handler {
 	// users
 	usersGroup := r.Group("/users")
 	usersGroup.GET("/getReview", h.UserHandler.GetReview)
+	usersGroup.GET("/overdueReviews", h.UserHandler.OverdueReviews)
 	usersGroup.POST("/setIsActive", h.UserHandler.SetIsActive)
+	usersGroup.POST("/update", h.UserHandler.Update)
+	usersGroup.POST("/addUnavailability", h.UserHandler.AddUnavailability)
+	usersGroup.GET("/getUnavailability", h.UserHandler.GetUnavailability)
+	usersGroup.POST("/deleteUnavailability", h.UserHandler.DeleteUnavailability)
 
 	// teams
 	teamsGroup := r.Group("/team")
 	teamsGroup.POST("/add", h.TeamHandler.Add)
 	teamsGroup.GET("/get", h.TeamHandler.Get)
+	teamsGroup.POST("/deactivateUsers", h.TeamHandler.DeactivateUsers)
+	teamsGroup.POST("/setCodeOwners", h.TeamHandler.SetCodeOwners)
+	teamsGroup.GET("/getCodeOwners", h.TeamHandler.GetCodeOwners)
+	teamsGroup.POST("/addRule", h.TeamHandler.AddRule)
+	teamsGroup.GET("/getRules", h.TeamHandler.GetRules)
+	teamsGroup.POST("/deleteRule", h.TeamHandler.DeleteRule)
+	teamsGroup.GET("/overdueReviews", h.TeamHandler.OverdueReviews)
 
 	// prs
 	prGroup := r.Group("/pullRequest")
+	prGroup.GET("/get", h.PrHandler.Get)
+	prGroup.GET("/list", h.PrHandler.List)
 	prGroup.POST("/create", h.PrHandler.Create)
+	prGroup.POST("/preview", h.PrHandler.Preview)
 	prGroup.POST("/merge", h.PrHandler.Merge)
+	prGroup.POST("/close", h.PrHandler.Close)
+	prGroup.POST("/reopen", h.PrHandler.Reopen)
 	prGroup.POST("/reassign", h.PrHandler.Reassign)
+	prGroup.POST("/addReviewer", h.PrHandler.AddReviewer)
+	prGroup.POST("/removeReviewer", h.PrHandler.RemoveReviewer)
+	prGroup.POST("/review", h.PrHandler.Review)
+	prGroup.GET("/history", h.PrHandler.History)
 
 	// stats
 	statsGroup := r.Group("/stats")
 	statsGroup.GET("/users", h.StatsHandler.GetUserStats)
 
+	// webhooks
+	webhooksGroup := r.Group("/webhooks")
+	webhooksGroup.POST("/add", h.WebhookHandler.Add)
+	webhooksGroup.GET("/list", h.WebhookHandler.List)
+	webhooksGroup.POST("/delete", h.WebhookHandler.Delete)
+	webhooksGroup.GET("/deadLetters", h.WebhookHandler.DeadLetters)
+
+	// events
+	r.GET("/events", h.EventsHandler.List)
+
 	// swagger
 	r.GET("/swagger", httpSwagger.WrapHandler)
 	r.GET("/swagger/", httpSwagger.WrapHandler)
 	r.GET("/swagger/index.html", httpSwagger.WrapHandler)
 	r.GET("/swagger/doc.json", httpSwagger.WrapHandler)
 
-	return r.Handler()
+	return withActor(r.Handler())
 }
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// cursorPayload - содержимое непрозрачного курсора потока событий.
+type cursorPayload struct {
+	TxID int64 `json:"x"`
+	ID   int64 `json:"i"`
+}
+
+// encodeCursor упаковывает позицию в потоке в base64url-строку для next_cursor.
+func encodeCursor(cursor domain.EventCursor) string {
+	payload, _ := json.Marshal(cursorPayload{TxID: cursor.TxID, ID: cursor.ID})
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// decodeCursor разбирает курсор, полученный от клиента; пустая строка - начало потока.
+func decodeCursor(value string) (domain.EventCursor, error) {
+	if value == "" {
+		return domain.EventCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return domain.EventCursor{}, domain.ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ID <= 0 {
+		return domain.EventCursor{}, domain.ErrInvalidCursor
+	}
+
+	return domain.EventCursor{TxID: payload.TxID, ID: payload.ID}, nil
+}
@@ -0,0 +1,26 @@
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type EventResponse struct {
+	EventID int64  `json:"event_id"`
+	Type    string `json:"type"`
+	// Payload - описание события, набор полей зависит от type.
+	Payload   json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt time.Time       `json:"created_at"`
+	// Cursor - позиция сразу после события.
+	Cursor string `json:"cursor"`
+}
+
+type EventsResponse struct {
+	Events []EventResponse `json:"events"`
+	// NextCursor передаётся как after в следующем запросе; если событий нет, равен переданному after.
+	NextCursor string `json:"next_cursor"`
+	// HeldBack - сколько уже закоммиченных событий после next_cursor задержано открытой более ранней транзакцией.
+	HeldBack int `json:"held_back"`
+	// LagSeconds - сколько секунд ждёт самое раннее задержанное событие, 0 - задержанных нет.
+	LagSeconds int64 `json:"lag_seconds"`
+}
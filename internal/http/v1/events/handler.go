@@ -0,0 +1,111 @@
+package events
+
+import (
+	"net/http"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/http/response"
+	"pr-reviewer-assigment-service/internal/service"
+	"strconv"
+	"time"
+)
+
+// defaultWait - сколько ждать новых событий, если wait не передан.
+const defaultWait = 30 * time.Second
+
+type EventsHandler struct {
+	eventService *service.EventService
+}
+
+func NewEventsHandler(eventService *service.EventService) *EventsHandler {
+	return &EventsHandler{
+		eventService: eventService,
+	}
+}
+
+// List godoc
+// @Summary Читать поток доменных событий (long-poll)
+// @Description
+//   - Возвращает события после курсора after в порядке записи: PR_CREATED, REVIEWER_ASSIGNED, REVIEWER_UNASSIGNED,
+//     PR_MERGED, PR_CLOSED, PR_REOPENED, REVIEW_SUBMITTED, USER_ACTIVATED, USER_DEACTIVATED, TEAM_MEMBERSHIP_CHANGED.
+//   - Без after поток читается с начала. Если новых событий нет, запрос ждёт их до wait секунд
+//     и возвращает пустой список; next_cursor передаётся как after в следующем запросе.
+//   - Событие попадает в поток в той же транзакции, что и изменение, и не пропускается при чтении по курсору.
+//   - Событие выдаётся только после завершения всех более ранних транзакций в БД, в том числе несвязанных
+//     (idle in transaction, долгий отчёт). Пока такая транзакция открыта, поток стоит, а long-poll
+//     завершается пустым ответом без ошибки. held_back и lag_seconds показывают, сколько закоммиченных
+//     событий задержано и как долго ждёт самое раннее из них.
+//
+// @Tags Events
+// @Produce json
+// @Param after query string false "Курсор next_cursor из предыдущего ответа"
+// @Param limit query int false "Размер пачки (по умолчанию 100, максимум 1000)"
+// @Param wait query int false "Сколько секунд ждать новых событий (по умолчанию 30, максимум 60, 0 - не ждать)"
+// @Success 200 {object} EventsResponse "События после курсора"
+// @Failure 400 {object} response.ErrorResponse "INVALID_CURSOR / INVALID_WAIT"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /events [get]
+func (handler *EventsHandler) List(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	query := r.URL.Query()
+
+	after, err := decodeCursor(query.Get("after"))
+	if err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_CURSOR", err.Error())
+		return
+	}
+
+	limit := domain.DefaultEventsLimit
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil && v > 0 {
+		limit = min(v, domain.MaxEventsLimit)
+	}
+
+	wait := defaultWait
+	if value := query.Get("wait"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			response.Error(w, http.StatusBadRequest, "INVALID_WAIT", "wait must be a non-negative number of seconds")
+			return
+		}
+		wait = min(time.Duration(seconds)*time.Second, domain.MaxEventsWait)
+	}
+
+	events, err := handler.eventService.Poll(r.Context(), after, limit, wait)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		return
+	}
+
+	eventsResponse := EventsResponse{
+		Events:     make([]EventResponse, 0, len(events)),
+		NextCursor: query.Get("after"),
+	}
+	next := after
+	for _, event := range events {
+		cursor := encodeCursor(event.Cursor)
+		eventsResponse.Events = append(eventsResponse.Events, EventResponse{
+			EventID:   event.ID,
+			Type:      string(event.Type),
+			Payload:   event.Payload,
+			CreatedAt: event.CreatedAt,
+			Cursor:    cursor,
+		})
+		eventsResponse.NextCursor = cursor
+		next = event.Cursor
+	}
+
+	lag, err := handler.eventService.Lag(r.Context(), next)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		return
+	}
+	if lag.HeldBack > 0 {
+		eventsResponse.HeldBack = lag.HeldBack
+		eventsResponse.LagSeconds = int64(time.Since(*lag.Since).Seconds())
+	}
+
+	response.JSON(w, http.StatusOK, eventsResponse)
+}
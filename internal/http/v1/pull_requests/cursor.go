@@ -0,0 +1,52 @@
+package pull_requests
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"pr-reviewer-assigment-service/internal/domain"
+	"time"
+)
+
+// cursorPayload - содержимое непрозрачного курсора списка PR.
+type cursorPayload struct {
+	Sort      domain.PRSortField `json:"s"`
+	Desc      bool               `json:"d"`
+	CreatedAt time.Time          `json:"t"`
+	ID        string             `json:"i"`
+}
+
+// encodeCursor упаковывает позицию в списке в base64url-строку для next_cursor.
+func encodeCursor(cursor *domain.PullRequestCursor) *string {
+	if cursor == nil {
+		return nil
+	}
+
+	payload, _ := json.Marshal(cursorPayload{
+		Sort:      cursor.Sort,
+		Desc:      cursor.Desc,
+		CreatedAt: cursor.CreatedAt,
+		ID:        cursor.ID,
+	})
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return &encoded
+}
+
+// decodeCursor разбирает курсор, полученный от клиента.
+func decodeCursor(value string) (*domain.PullRequestCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil || payload.ID == "" {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &domain.PullRequestCursor{
+		Sort:      payload.Sort,
+		Desc:      payload.Desc,
+		CreatedAt: payload.CreatedAt,
+		ID:        payload.ID,
+	}, nil
+}
@@ -6,6 +6,12 @@ type CreatePRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	// ReviewersCount переопределяет required_reviewers команды автора.
+	ReviewersCount *int `json:"reviewers_count,omitempty"`
+	// ChangedFiles - изменённые файлы; их владельцы по правилам команды назначаются первыми.
+	ChangedFiles []string `json:"changed_files,omitempty"`
+	// Tags - теги PR; предпочтение отдаётся ревьюверам с хотя бы одним совпадающим тегом.
+	Tags []string `json:"tags,omitempty"`
 }
 
 type PullRequestResponse struct {
@@ -16,6 +22,36 @@ type PullRequestResponse struct {
 	AssignedReviewers []string   `json:"assigned_reviewers"`
 	MergedAt          *time.Time `json:"mergedAt,omitempty"`
 	ReplacedBy        *string    `json:"replaced_by,omitempty"`
+	Version           int64      `json:"version"`
+	// заполняются при назначении ревьюверов (create/reassign)
+	RequiredReviewers     int                `json:"required_reviewers,omitempty"`
+	InsufficientReviewers bool               `json:"insufficient_reviewers,omitempty"`
+	FallbackReviewers     []FallbackReviewer `json:"fallback_reviewers,omitempty"`
+	// CodeOwnerReviewers заполняется при create, если среди ревьюверов есть владельцы изменённых файлов.
+	CodeOwnerReviewers []CodeOwnerReviewer `json:"code_owner_reviewers,omitempty"`
+	// RuleEffects заполняется при create/reassign, если на выбор повлияли правила команды.
+	RuleEffects []RuleEffect `json:"rule_effects,omitempty"`
+}
+
+// RuleEffect - как правило команды повлияло на выбор ревьюверов:
+// EXCLUDED, REQUIRED, SATISFIED или UNSATISFIED (user_id нет).
+type RuleEffect struct {
+	RuleID int64  `json:"rule_id"`
+	Type   string `json:"type"`
+	UserID string `json:"user_id,omitempty"`
+	Effect string `json:"effect"`
+}
+
+// FallbackReviewer - ревьювер, взятый из резервной команды, когда в команде автора не хватило кандидатов.
+type FallbackReviewer struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+// CodeOwnerReviewer - ревьювер, назначенный как владелец изменённых файлов.
+type CodeOwnerReviewer struct {
+	UserID  string `json:"user_id"`
+	Pattern string `json:"pattern"`
 }
 
 type CreatePRResponse struct {
@@ -33,8 +69,129 @@ type MergePRResponse struct {
 type ReassignPRRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldReviewerID string `json:"old_reviewer_id"`
+	// NewReviewerID - кому передать ревью; если не задан, замена подбирается автоматически.
+	NewReviewerID *string `json:"new_reviewer_id,omitempty"`
+	// ExpectedVersion - версия PR, которую видел клиент; альтернатива заголовку If-Match.
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
 }
 
 type ReassignPRResponse struct {
 	PullRequest PullRequestResponse `json:"pr"`
 }
+
+type ReviewerResponse struct {
+	UserID     string    `json:"user_id"`
+	AssignedAt time.Time `json:"assigned_at"`
+	// State - PENDING, APPROVED, CHANGES_REQUESTED или DISMISSED.
+	State      string     `json:"state"`
+	ReviewedAt *time.Time `json:"reviewed_at,omitempty"`
+}
+
+type PullRequestDetailsResponse struct {
+	PullRequestID     string             `json:"pull_request_id"`
+	PullRequestName   string             `json:"pull_request_name"`
+	AuthorID          string             `json:"author_id"`
+	Status            string             `json:"status"`
+	Reviewers         []ReviewerResponse `json:"reviewers"`
+	RequiredReviewers int                `json:"required_reviewers"`
+	Tags              []string           `json:"tags,omitempty"`
+	Version           int64              `json:"version"`
+	CreatedAt         time.Time          `json:"created_at"`
+	MergedAt          *time.Time         `json:"merged_at,omitempty"`
+	ClosedAt          *time.Time         `json:"closed_at,omitempty"`
+}
+
+type GetPRResponse struct {
+	PullRequest PullRequestDetailsResponse `json:"pr"`
+}
+
+type ChangeStatusPRRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+}
+
+type ChangeStatusPRResponse struct {
+	PullRequest PullRequestDetailsResponse `json:"pr"`
+}
+
+type ListPRResponse struct {
+	Items []PullRequestDetailsResponse `json:"items"`
+	Total int                          `json:"total"`
+	Limit int                          `json:"limit"`
+	// NextCursor передаётся в cursor для следующей страницы; отсутствует на последней странице.
+	NextCursor *string `json:"next_cursor,omitempty"`
+}
+
+type AssignmentEventResponse struct {
+	ID                 int64     `json:"id"`
+	EventType          string    `json:"event_type"`
+	ReviewerID         *string   `json:"reviewer_id,omitempty"`
+	PreviousReviewerID *string   `json:"previous_reviewer_id,omitempty"`
+	ActorID            *string   `json:"actor_id,omitempty"`
+	Reason             string    `json:"reason"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+type HistoryResponse struct {
+	PullRequestID string                    `json:"pull_request_id"`
+	Events        []AssignmentEventResponse `json:"events"`
+}
+
+type PreviewPRRequest struct {
+	AuthorID string `json:"author_id"`
+	// ReviewersCount переопределяет required_reviewers команды автора.
+	ReviewersCount *int     `json:"reviewers_count,omitempty"`
+	ChangedFiles   []string `json:"changed_files,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+}
+
+// CandidateResponse - участник команды автора и решение по нему.
+// reason: CODE_OWNER, REQUIRED_BY_RULE, SELECTED (picked = true) или
+// AUTHOR, INACTIVE, UNAVAILABLE, EXCLUDED_BY_RULE, AT_CAPACITY, NOT_SELECTED.
+type CandidateResponse struct {
+	UserID         string   `json:"user_id"`
+	Username       string   `json:"username"`
+	IsActive       bool     `json:"is_active"`
+	Load           *float64 `json:"load,omitempty"`
+	OpenReviews    *int64   `json:"open_reviews,omitempty"`
+	MaxOpenReviews *int     `json:"max_open_reviews,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Picked         bool     `json:"picked"`
+	Reason         string   `json:"reason"`
+	RuleID         *int64   `json:"rule_id,omitempty"`
+	Pattern        string   `json:"pattern,omitempty"`
+}
+
+type PreviewPRResponse struct {
+	AuthorID              string              `json:"author_id"`
+	TeamName              string              `json:"team_name"`
+	AssignedReviewers     []string            `json:"assigned_reviewers"`
+	RequiredReviewers     int                 `json:"required_reviewers"`
+	InsufficientReviewers bool                `json:"insufficient_reviewers,omitempty"`
+	FallbackReviewers     []FallbackReviewer  `json:"fallback_reviewers,omitempty"`
+	CodeOwnerReviewers    []CodeOwnerReviewer `json:"code_owner_reviewers,omitempty"`
+	RuleEffects           []RuleEffect        `json:"rule_effects,omitempty"`
+	Candidates            []CandidateResponse `json:"candidates"`
+}
+
+// ChangeReviewerRequest - ручное добавление или снятие ревьювера.
+type ChangeReviewerRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	// ExpectedVersion - версия PR, которую видел клиент; альтернатива заголовку If-Match.
+	ExpectedVersion *int64 `json:"expected_version,omitempty"`
+}
+
+type ChangeReviewerResponse struct {
+	PullRequest PullRequestResponse `json:"pr"`
+}
+
+type ReviewPRRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	// State - APPROVED, CHANGES_REQUESTED или DISMISSED.
+	State string `json:"state"`
+}
+
+type ReviewPRResponse struct {
+	PullRequest PullRequestDetailsResponse `json:"pr"`
+}
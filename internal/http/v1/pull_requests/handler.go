@@ -1,12 +1,18 @@
 package pull_requests
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"pr-reviewer-assigment-service/internal/domain"
 	"pr-reviewer-assigment-service/internal/http/response"
 	"pr-reviewer-assigment-service/internal/service"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type PullRequestHandler struct {
@@ -18,18 +24,29 @@ func NewPullRequestHandler(prService *service.PullRequestService) *PullRequestHa
 }
 
 // Create godoc
-// @Summary Создать PR и автоматически назначить до 2 ревьюверов из команды автора
+// @Summary Создать PR и автоматически назначить ревьюверов из команды автора
 // @Description
 //
-//	Создаёт pull request и выбирает до двух ревьюверов из команды автора с минимальным количеством уже назначенных ревью.
+//	Создаёт pull request и выбирает до required_reviewers ревьюверов из команды автора
+//	(reviewers_count в запросе переопределяет значение команды)
+//	по стратегии команды (selection_strategy, по умолчанию — минимальное количество уже назначенных ревью).
+//	Если в команде автора не хватило кандидатов, недостающие берутся из резервных команд (fallback_teams)
+//	и перечисляются в fallback_reviewers.
+//	Если переданы changed_files, сначала назначаются владельцы этих файлов по правилам команды автора
+//	(/team/setCodeOwners), даже сверх required_reviewers; они перечисляются в code_owner_reviewers,
+//	а оставшиеся места заполняются по стратегии.
+//	Если переданы tags, в каждой команде сначала выбираются участники с хотя бы одним из этих тегов,
+//	затем остальные; внутри группы порядок задаёт стратегия. Теги сохраняются и учитываются при reassign.
+//	Правила команды для автора (/team/addRule) применяются до балансировки: NEVER_REVIEWS исключает
+//	ревьювера, REQUIRES_TAG добавляет участника с нужным тегом. Их влияние возвращается в rule_effects.
 //	Автор PR никогда не попадает в список ревьюверов.
 //
 // @Tags PullRequests
 // @Accept json
 // @Produce json
 // @Param request body CreatePRRequest true "Параметры для создания PR"
-// @Success 201 {object} CreatePRResponse "Созданный PR с назначенными ревьюверами"
-// @Failure 400 {object} response.ErrorResponse"INVALID_JSON"
+// @Success 201 {object} CreatePRResponse "Созданный PR с назначенными ревьюверами (insufficient_reviewers, если кандидатов не хватило)"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_REVIEWERS_COUNT / INVALID_TAG"
 // @Failure 409 {object} response.ErrorResponse "PR_EXISTS"
 // @Failure 404 {object} response.ErrorResponse "NOT_FOUND (author or team not found)"
 // @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
@@ -47,9 +64,21 @@ func (handler *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	prInfo, err := handler.prService.Create(r.Context(), request.PullRequestID, request.PullRequestName, request.AuthorID)
+	prInfo, err := handler.prService.Create(
+		r.Context(),
+		request.PullRequestID,
+		request.PullRequestName,
+		request.AuthorID,
+		request.ReviewersCount,
+		request.ChangedFiles,
+		request.Tags,
+	)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrInvalidReviewersCount):
+			response.Error(w, http.StatusBadRequest, "INVALID_REVIEWERS_COUNT", err.Error())
+		case errors.Is(err, domain.ErrInvalidTag):
+			response.Error(w, http.StatusBadRequest, "INVALID_TAG", err.Error())
 		case errors.Is(err, domain.ErrPRIsExists):
 			response.Error(w, http.StatusConflict, "PR_EXISTS", err.Error())
 		case errors.Is(err, domain.ErrUserNotFound):
@@ -64,17 +93,112 @@ func (handler *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request
 
 	prResponse := CreatePRResponse{
 		PullRequest: PullRequestResponse{
-			PullRequestID:     prInfo.PullRequestID,
-			PullRequestName:   prInfo.PullRequestName,
-			AuthorID:          prInfo.AuthorID,
-			Status:            string(prInfo.Status),
-			AssignedReviewers: prInfo.AssignedReviewers,
+			PullRequestID:         prInfo.PullRequestID,
+			PullRequestName:       prInfo.PullRequestName,
+			AuthorID:              prInfo.AuthorID,
+			Status:                string(prInfo.Status),
+			AssignedReviewers:     prInfo.AssignedReviewers,
+			Version:               prInfo.Version,
+			RequiredReviewers:     prInfo.RequiredReviewers,
+			InsufficientReviewers: prInfo.InsufficientReviewers,
+			FallbackReviewers:     toFallbackReviewers(prInfo.FallbackReviewers),
+			CodeOwnerReviewers:    toCodeOwnerReviewers(prInfo.CodeOwnerReviewers),
+			RuleEffects:           toRuleEffects(prInfo.RuleEffects),
 		},
 	}
 
+	setETag(w, prInfo.Version)
 	response.JSON(w, http.StatusCreated, prResponse)
 }
 
+// Preview godoc
+// @Summary Показать, кого назначит create, ничего не сохраняя
+// @Description
+//
+//	Выполняет тот же выбор ревьюверов, что и /pullRequest/create (стратегия, резервные команды,
+//	владельцы файлов, теги, правила команды), но не создаёт PR и не назначает ревьюверов.
+//	В candidates перечислены все участники команды автора с нагрузкой, флагом активности
+//	и причиной, по которой участник выбран или пропущен.
+//	При стратегии WEIGHTED_RANDOM create может выбрать других ревьюверов.
+//
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body PreviewPRRequest true "Автор и параметры будущего PR"
+// @Success 200 {object} PreviewPRResponse "Выбранные ревьюверы и решение по каждому участнику команды"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_REVIEWERS_COUNT / INVALID_TAG"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND (author or team not found)"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/preview [post]
+func (handler *PullRequestHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request PreviewPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	preview, err := handler.prService.Preview(
+		r.Context(),
+		request.AuthorID,
+		request.ReviewersCount,
+		request.ChangedFiles,
+		request.Tags,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReviewersCount):
+			response.Error(w, http.StatusBadRequest, "INVALID_REVIEWERS_COUNT", err.Error())
+		case errors.Is(err, domain.ErrInvalidTag):
+			response.Error(w, http.StatusBadRequest, "INVALID_TAG", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrTeamNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	previewResponse := PreviewPRResponse{
+		AuthorID:              preview.AuthorID,
+		TeamName:              preview.TeamName,
+		AssignedReviewers:     preview.AssignedReviewers,
+		RequiredReviewers:     preview.RequiredReviewers,
+		InsufficientReviewers: preview.InsufficientReviewers,
+		FallbackReviewers:     toFallbackReviewers(preview.FallbackReviewers),
+		CodeOwnerReviewers:    toCodeOwnerReviewers(preview.CodeOwnerReviewers),
+		RuleEffects:           toRuleEffects(preview.RuleEffects),
+		Candidates:            make([]CandidateResponse, 0, len(preview.Candidates)),
+	}
+	if previewResponse.AssignedReviewers == nil {
+		previewResponse.AssignedReviewers = []string{}
+	}
+	for _, candidate := range preview.Candidates {
+		previewResponse.Candidates = append(previewResponse.Candidates, CandidateResponse{
+			UserID:         candidate.UserID,
+			Username:       candidate.Username,
+			IsActive:       candidate.IsActive,
+			Load:           candidate.Load,
+			OpenReviews:    candidate.OpenReviews,
+			MaxOpenReviews: candidate.MaxOpenReviews,
+			Tags:           candidate.Tags,
+			Picked:         candidate.Picked,
+			Reason:         string(candidate.Reason),
+			RuleID:         candidate.RuleID,
+			Pattern:        candidate.Pattern,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, previewResponse)
+}
+
 // Merge godoc
 // @Summary Пометить PR как MERGED (идемпотентная операция)
 // @Description
@@ -82,7 +206,9 @@ func (handler *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request
 //	Завершает pull request и помечает его как MERGED.
 //	Если PR уже в статусе MERGED — операция идемпотентна:
 //	ничего не изменяется, и возвращаются текущие данные PR.
-//	Если PR не существует — возвращается ошибка.
+//	Если PR не существует — возвращается ошибка. Закрытый PR нужно сначала переоткрыть.
+//	Если команда автора задала required_approvals, открытый PR мержится только при нужном числе
+//	ревьюверов в состоянии APPROVED (/pullRequest/review), иначе возвращается NOT_APPROVED.
 //
 // @Tags PullRequests
 // @Accept json
@@ -91,6 +217,7 @@ func (handler *PullRequestHandler) Create(w http.ResponseWriter, r *http.Request
 // @Success 200 {object} MergePRResponse "PR успешно помечен как MERGED"
 // @Failure 400 {object} response.ErrorResponse "INVALID_JSON"
 // @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 409 {object} response.ErrorResponse "PR_CLOSED / NOT_APPROVED"
 // @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
 // @Router /pullRequest/merge [post]
 func (handler *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request) {
@@ -110,6 +237,10 @@ func (handler *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request)
 		switch {
 		case errors.Is(err, domain.ErrPRNotFound):
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPRClosed):
+			response.Error(w, http.StatusConflict, "PR_CLOSED", err.Error())
+		case errors.Is(err, domain.ErrNotApproved):
+			response.Error(w, http.StatusConflict, "NOT_APPROVED", err.Error())
 		default:
 			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
 		}
@@ -124,9 +255,11 @@ func (handler *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request)
 			Status:            string(prMergeInfo.Status),
 			AssignedReviewers: prMergeInfo.AssignedReviewers,
 			MergedAt:          prMergeInfo.MergedAt,
+			Version:           prMergeInfo.Version,
 		},
 	}
 
+	setETag(w, prMergeInfo.Version)
 	response.JSON(w, http.StatusOK, prMergeResponse)
 }
 
@@ -135,18 +268,27 @@ func (handler *PullRequestHandler) Merge(w http.ResponseWriter, r *http.Request)
 // @Description
 //
 //	Заменяет конкретного ревьювера в PR на другого участника той же команды.
-//	Новый ревьювер выбирается из активных участников команды с минимальным числом назначенных ревью.
+//	Новый ревьювер выбирается из активных участников команды автора по стратегии команды,
+//	а если их нет — из резервных команд (попадает в fallback_reviewers).
+//	Правила команды для автора применяются так же, как при create, и попадают в rule_effects.
 //	Автор PR никогда не попадает в список ревьюверов.
 //	Если нет доступного кандидата — возвращается ошибка NO_CANDIDATE.
+//	Если передан new_reviewer_id, ревью передаётся этому пользователю без подбора: он должен быть
+//	активным участником команды автора (или её резервной команды), не автором и не ревьювером PR,
+//	не в отпуске, не исчерпавшим max_open_reviews и не исключённым правилом NEVER_REVIEWS,
+//	иначе возвращается NO_CANDIDATE. Недостающие до required_reviewers в этом случае не добираются.
+//	Ожидаемую версию PR можно передать в заголовке If-Match или в поле expected_version:
+//	если PR успел измениться, возвращается CONFLICT_VERSION.
 //
 // @Tags PullRequests
 // @Accept json
 // @Produce json
-// @Param request body ReassignPRRequest true "PR и старый ревьювер"
+// @Param If-Match header string false "Ожидаемая версия PR (ETag из предыдущего ответа)"
+// @Param request body ReassignPRRequest true "PR, старый ревьювер и, при необходимости, новый"
 // @Success 200 {object} ReassignPRResponse "Успешное переназначение ревьювера"
-// @Failure 400 {object} response.ErrorResponse "INVALID_JSON"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_VERSION"
 // @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
-// @Failure 409 {object} response.ErrorResponse "PR_MERGED / NO_CANDIDATE / NOT_ASSIGNED"
+// @Failure 409 {object} response.ErrorResponse "PR_MERGED / PR_CLOSED / NO_CANDIDATE / NOT_ASSIGNED / CONFLICT_VERSION"
 // @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
 // @Router /pullRequest/reassign [post]
 func (handler *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Request) {
@@ -162,13 +304,33 @@ func (handler *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	prAssgs, err := handler.prService.Reassign(r.Context(), request.PullRequestID, request.OldReviewerID)
+	expectedVersion := request.ExpectedVersion
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" {
+		version, err := parseETag(ifMatch)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "INVALID_VERSION", "If-Match must contain PR version")
+			return
+		}
+		expectedVersion = &version
+	}
+
+	prAssgs, err := handler.prService.Reassign(
+		r.Context(),
+		request.PullRequestID,
+		request.OldReviewerID,
+		request.NewReviewerID,
+		expectedVersion,
+	)
 	if err != nil {
 		switch {
+		case errors.Is(err, domain.ErrVersionConflict):
+			response.Error(w, http.StatusConflict, "CONFLICT_VERSION", err.Error())
 		case errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrPRNotFound):
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
 		case errors.Is(err, domain.ErrPRMerged):
 			response.Error(w, http.StatusConflict, "PR_MERGED", err.Error())
+		case errors.Is(err, domain.ErrPRClosed):
+			response.Error(w, http.StatusConflict, "PR_CLOSED", err.Error())
 		case errors.Is(err, domain.ErrIsNotAssigned):
 			response.Error(w, http.StatusConflict, "NOT_ASSIGNED", err.Error())
 		case errors.Is(err, domain.ErrIsNoCandidates):
@@ -181,14 +343,598 @@ func (handler *PullRequestHandler) Reassign(w http.ResponseWriter, r *http.Reque
 
 	prAssgsResponse := ReassignPRResponse{
 		PullRequest: PullRequestResponse{
-			PullRequestID:     prAssgs.PullRequestID,
-			PullRequestName:   prAssgs.PullRequestName,
-			AuthorID:          prAssgs.AuthorID,
-			Status:            string(prAssgs.Status),
-			AssignedReviewers: prAssgs.AssignedReviewers,
-			ReplacedBy:        prAssgs.ReplacedBy,
+			PullRequestID:         prAssgs.PullRequestID,
+			PullRequestName:       prAssgs.PullRequestName,
+			AuthorID:              prAssgs.AuthorID,
+			Status:                string(prAssgs.Status),
+			AssignedReviewers:     prAssgs.AssignedReviewers,
+			ReplacedBy:            prAssgs.ReplacedBy,
+			Version:               prAssgs.Version,
+			RequiredReviewers:     prAssgs.RequiredReviewers,
+			InsufficientReviewers: prAssgs.InsufficientReviewers,
+			FallbackReviewers:     toFallbackReviewers(prAssgs.FallbackReviewers),
+			RuleEffects:           toRuleEffects(prAssgs.RuleEffects),
 		},
 	}
 
+	setETag(w, prAssgs.Version)
 	response.JSON(w, http.StatusOK, prAssgsResponse)
 }
+
+// AddReviewer godoc
+// @Summary Вручную добавить ревьювера в открытый PR
+// @Description
+//
+//	Назначает reviewer_id ревьювером сверх выбранных автоматически. Ревьювер должен быть активен,
+//	не в отпуске (REVIEWER_UNAVAILABLE), не может быть автором PR и не должен быть исключён правилом
+//	NEVER_REVIEWS команды автора (REVIEWER_EXCLUDED). Ревьювер может быть из любой команды,
+//	лимит открытых ревью не проверяется.
+//	В историю назначений пишется событие ASSIGNED с причиной reviewer_added.
+//
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param If-Match header string false "Ожидаемая версия PR (ETag из предыдущего ответа)"
+// @Param request body ChangeReviewerRequest true "PR и добавляемый ревьювер"
+// @Success 200 {object} ChangeReviewerResponse "PR с новым списком ревьюверов"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_VERSION / MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 409 {object} response.ErrorResponse "PR_MERGED / PR_CLOSED / REVIEWER_IS_AUTHOR / REVIEWER_INACTIVE / REVIEWER_UNAVAILABLE / REVIEWER_EXCLUDED / ALREADY_ASSIGNED / CONFLICT_VERSION"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/addReviewer [post]
+func (handler *PullRequestHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	handler.changeReviewer(w, r, handler.prService.AddReviewer)
+}
+
+// RemoveReviewer godoc
+// @Summary Вручную снять ревьювера с открытого PR без замены
+// @Description
+//
+//	Снимает reviewer_id с PR; замена не подбирается, поэтому PR может остаться
+//	с insufficient_reviewers. В историю назначений пишется событие UNASSIGNED с причиной reviewer_removed.
+//
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param If-Match header string false "Ожидаемая версия PR (ETag из предыдущего ответа)"
+// @Param request body ChangeReviewerRequest true "PR и снимаемый ревьювер"
+// @Success 200 {object} ChangeReviewerResponse "PR с новым списком ревьюверов"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_VERSION / MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 409 {object} response.ErrorResponse "PR_MERGED / PR_CLOSED / NOT_ASSIGNED / CONFLICT_VERSION"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/removeReviewer [post]
+func (handler *PullRequestHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	handler.changeReviewer(w, r, handler.prService.RemoveReviewer)
+}
+
+func (handler *PullRequestHandler) changeReviewer(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, prID, reviewerID string, expectedVersion *int64) (*domain.PullRequestAssignment, error),
+) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request ChangeReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+	if request.PullRequestID == "" || request.ReviewerID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "pull_request_id and reviewer_id are required")
+		return
+	}
+
+	expectedVersion := request.ExpectedVersion
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != "*" {
+		version, err := parseETag(ifMatch)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "INVALID_VERSION", "If-Match must contain PR version")
+			return
+		}
+		expectedVersion = &version
+	}
+
+	prAssgs, err := change(r.Context(), request.PullRequestID, request.ReviewerID, expectedVersion)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrVersionConflict):
+			response.Error(w, http.StatusConflict, "CONFLICT_VERSION", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound) || errors.Is(err, domain.ErrPRNotFound) ||
+			errors.Is(err, domain.ErrTeamNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPRMerged):
+			response.Error(w, http.StatusConflict, "PR_MERGED", "pull request is merged")
+		case errors.Is(err, domain.ErrPRClosed):
+			response.Error(w, http.StatusConflict, "PR_CLOSED", err.Error())
+		case errors.Is(err, domain.ErrReviewerIsAuthor):
+			response.Error(w, http.StatusConflict, "REVIEWER_IS_AUTHOR", err.Error())
+		case errors.Is(err, domain.ErrReviewerInactive):
+			response.Error(w, http.StatusConflict, "REVIEWER_INACTIVE", err.Error())
+		case errors.Is(err, domain.ErrReviewerUnavailable):
+			response.Error(w, http.StatusConflict, "REVIEWER_UNAVAILABLE", err.Error())
+		case errors.Is(err, domain.ErrReviewerExcluded):
+			response.Error(w, http.StatusConflict, "REVIEWER_EXCLUDED", err.Error())
+		case errors.Is(err, domain.ErrAlreadyAssigned):
+			response.Error(w, http.StatusConflict, "ALREADY_ASSIGNED", err.Error())
+		case errors.Is(err, domain.ErrIsNotAssigned):
+			response.Error(w, http.StatusConflict, "NOT_ASSIGNED", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	prResponse := ChangeReviewerResponse{
+		PullRequest: PullRequestResponse{
+			PullRequestID:         prAssgs.PullRequestID,
+			PullRequestName:       prAssgs.PullRequestName,
+			AuthorID:              prAssgs.AuthorID,
+			Status:                string(prAssgs.Status),
+			AssignedReviewers:     prAssgs.AssignedReviewers,
+			Version:               prAssgs.Version,
+			RequiredReviewers:     prAssgs.RequiredReviewers,
+			InsufficientReviewers: prAssgs.InsufficientReviewers,
+		},
+	}
+
+	setETag(w, prAssgs.Version)
+	response.JSON(w, http.StatusOK, prResponse)
+}
+
+// Review godoc
+// @Summary Отправить вердикт ревьювера по PR
+// @Description
+//
+//	Сохраняет состояние ревью reviewer_id в открытом PR: APPROVED, CHANGES_REQUESTED
+//	или DISMISSED (отзыв прежнего вердикта). Новый ревьювер находится в состоянии PENDING;
+//	повторный вердикт заменяет предыдущий, reviewed_at обновляется. Версия PR увеличивается,
+//	в историю пишется REVIEWED (reason — вердикт в нижнем регистре).
+//	Если передан X-Actor-ID, он должен совпадать с reviewer_id, иначе возвращается NOT_REVIEWER.
+//	Одобрения учитываются при merge, если команда автора задала required_approvals.
+//
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param X-Actor-ID header string false "Кто отправляет вердикт; должен совпадать с reviewer_id"
+// @Param request body ReviewPRRequest true "PR, ревьювер и вердикт"
+// @Success 200 {object} ReviewPRResponse "PR с состояниями ревьюверов"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / MISSING_FIELD / INVALID_REVIEW_STATE"
+// @Failure 403 {object} response.ErrorResponse "NOT_REVIEWER"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 409 {object} response.ErrorResponse "PR_MERGED / PR_CLOSED / NOT_ASSIGNED"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/review [post]
+func (handler *PullRequestHandler) Review(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request ReviewPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+	if request.PullRequestID == "" || request.ReviewerID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "pull_request_id and reviewer_id are required")
+		return
+	}
+
+	pr, err := handler.prService.SubmitReview(r.Context(), request.PullRequestID, request.ReviewerID, domain.ReviewState(request.State))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReviewState):
+			response.Error(w, http.StatusBadRequest, "INVALID_REVIEW_STATE", err.Error())
+		case errors.Is(err, domain.ErrNotReviewer):
+			response.Error(w, http.StatusForbidden, "NOT_REVIEWER", err.Error())
+		case errors.Is(err, domain.ErrPRNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPRMerged):
+			response.Error(w, http.StatusConflict, "PR_MERGED", "pull request is merged")
+		case errors.Is(err, domain.ErrPRClosed):
+			response.Error(w, http.StatusConflict, "PR_CLOSED", err.Error())
+		case errors.Is(err, domain.ErrIsNotAssigned):
+			response.Error(w, http.StatusConflict, "NOT_ASSIGNED", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	setETag(w, pr.Version)
+	response.JSON(w, http.StatusOK, ReviewPRResponse{PullRequest: toDetailsResponse(pr)})
+}
+
+// Close godoc
+// @Summary Закрыть PR без merge (идемпотентная операция)
+// @Description
+//
+//	Переводит открытый PR в статус CLOSED и выставляет closed_at.
+//	Ревьюверы остаются в PR, но закрытый PR не учитывается в нагрузке ревьюверов,
+//	статистике и /users/getReview. Повторный вызов возвращает текущее состояние.
+//	Смерженный PR закрыть нельзя.
+//
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body ChangeStatusPRRequest true "Идентификатор PR"
+// @Success 200 {object} ChangeStatusPRResponse "PR в статусе CLOSED"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 409 {object} response.ErrorResponse "PR_MERGED"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/close [post]
+func (handler *PullRequestHandler) Close(w http.ResponseWriter, r *http.Request) {
+	handler.changeStatus(w, r, handler.prService.Close)
+}
+
+// Reopen godoc
+// @Summary Переоткрыть закрытый PR (идемпотентная операция)
+// @Description
+//
+//	Возвращает закрытый PR в статус OPEN и сбрасывает closed_at; ревьюверы сохраняются.
+//	Для открытого PR возвращает текущее состояние. Смерженный PR переоткрыть нельзя.
+//
+// @Tags PullRequests
+// @Accept json
+// @Produce json
+// @Param request body ChangeStatusPRRequest true "Идентификатор PR"
+// @Success 200 {object} ChangeStatusPRResponse "PR в статусе OPEN"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 409 {object} response.ErrorResponse "PR_MERGED"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/reopen [post]
+func (handler *PullRequestHandler) Reopen(w http.ResponseWriter, r *http.Request) {
+	handler.changeStatus(w, r, handler.prService.Reopen)
+}
+
+// changeStatus - общий обработчик close/reopen.
+func (handler *PullRequestHandler) changeStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	change func(ctx context.Context, prID string) (*domain.PullRequestDetails, error),
+) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request ChangeStatusPRRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	pr, err := change(r.Context(), request.PullRequestID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPRNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		case errors.Is(err, domain.ErrPRMerged):
+			response.Error(w, http.StatusConflict, "PR_MERGED", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	setETag(w, pr.Version)
+	response.JSON(w, http.StatusOK, ChangeStatusPRResponse{PullRequest: toDetailsResponse(pr)})
+}
+
+// Get godoc
+// @Summary Получить PR с ревьюверами
+// @Description
+//
+//	Возвращает PR: название, автора, статус, назначенных ревьюверов со временем назначения (assigned_at),
+//	created_at и merged_at. Версия PR дублируется в заголовке ETag.
+//
+// @Tags PullRequests
+// @Produce json
+// @Param pull_request_id query string true "Идентификатор PR"
+// @Success 200 {object} GetPRResponse "PR с ревьюверами"
+// @Failure 400 {object} response.ErrorResponse "MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/get [get]
+func (handler *PullRequestHandler) Get(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "pull_request_id field is required")
+		return
+	}
+
+	pr, err := handler.prService.Get(r.Context(), prID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPRNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	prResponse := GetPRResponse{PullRequest: toDetailsResponse(pr)}
+
+	setETag(w, pr.Version)
+	response.JSON(w, http.StatusOK, prResponse)
+}
+
+// List godoc
+// @Summary Список PR с фильтрами и пагинацией
+// @Description
+//
+//	Возвращает PR, подходящие под все переданные фильтры. Даты — в формате RFC 3339,
+//	диапазоны полуоткрытые: [from, to). team_name — команда автора PR.
+//	Пагинация курсорная: next_cursor из ответа передаётся в cursor для следующей страницы
+//	вместе с теми же sort и order; на последней странице next_cursor отсутствует.
+//
+// @Tags PullRequests
+// @Produce json
+// @Param status query string false "OPEN / MERGED / CLOSED"
+// @Param author_id query string false "Автор PR"
+// @Param reviewer_id query string false "Назначенный ревьювер"
+// @Param team_name query string false "Команда автора PR"
+// @Param created_from query string false "Создан не раньше (RFC 3339)"
+// @Param created_to query string false "Создан раньше (RFC 3339)"
+// @Param merged_from query string false "Смержен не раньше (RFC 3339)"
+// @Param merged_to query string false "Смержен раньше (RFC 3339)"
+// @Param sort query string false "created_at (по умолчанию) / pull_request_id"
+// @Param order query string false "desc (по умолчанию) / asc"
+// @Param limit query int false "Размер страницы (по умолчанию 50, максимум 100)"
+// @Param cursor query string false "next_cursor из предыдущего ответа"
+// @Success 200 {object} ListPRResponse "Страница PR"
+// @Failure 400 {object} response.ErrorResponse "INVALID_QUERY / INVALID_CURSOR"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/list [get]
+func (handler *PullRequestHandler) List(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	query, err := parseListQuery(r.URL.Query())
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCursor) {
+			response.Error(w, http.StatusBadRequest, "INVALID_CURSOR", err.Error())
+			return
+		}
+		response.Error(w, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+		return
+	}
+
+	page, err := handler.prService.List(r.Context(), query)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidListQuery):
+			response.Error(w, http.StatusBadRequest, "INVALID_QUERY", err.Error())
+		case errors.Is(err, domain.ErrInvalidCursor):
+			response.Error(w, http.StatusBadRequest, "INVALID_CURSOR", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	listResponse := ListPRResponse{
+		Items:      make([]PullRequestDetailsResponse, 0, len(page.Items)),
+		Total:      page.Total,
+		Limit:      page.Limit,
+		NextCursor: encodeCursor(page.NextCursor),
+	}
+	for i := range page.Items {
+		listResponse.Items = append(listResponse.Items, toDetailsResponse(&page.Items[i]))
+	}
+
+	response.JSON(w, http.StatusOK, listResponse)
+}
+
+// parseListQuery разбирает query-параметры /pullRequest/list; limit, как в /stats/users,
+// приводится к допустимому значению вместо ошибки.
+func parseListQuery(values url.Values) (domain.PullRequestListQuery, error) {
+	query := domain.PullRequestListQuery{
+		PullRequestFilter: domain.PullRequestFilter{
+			Status:     domain.PRStatus(values.Get("status")),
+			AuthorID:   values.Get("author_id"),
+			ReviewerID: values.Get("reviewer_id"),
+			TeamName:   values.Get("team_name"),
+		},
+		Sort: domain.PRSortField(values.Get("sort")),
+		Desc: true,
+	}
+
+	switch values.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Desc = false
+	default:
+		return query, fmt.Errorf("%w: order must be asc or desc", domain.ErrInvalidListQuery)
+	}
+
+	if v, err := strconv.Atoi(values.Get("limit")); err == nil && v > 0 {
+		query.Limit = v
+	}
+
+	timeParams := []struct {
+		name string
+		dest **time.Time
+	}{
+		{"created_from", &query.CreatedFrom},
+		{"created_to", &query.CreatedTo},
+		{"merged_from", &query.MergedFrom},
+		{"merged_to", &query.MergedTo},
+	}
+	for _, param := range timeParams {
+		value := values.Get(param.name)
+		if value == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return query, fmt.Errorf("%w: %s must be RFC 3339", domain.ErrInvalidListQuery, param.name)
+		}
+		*param.dest = &t
+	}
+
+	if value := values.Get("cursor"); value != "" {
+		cursor, err := decodeCursor(value)
+		if err != nil {
+			return query, err
+		}
+		query.Cursor = cursor
+	}
+
+	return query, nil
+}
+
+// History godoc
+// @Summary Получить историю назначений ревьюверов PR
+// @Description
+//
+//	Возвращает append-only историю назначений PR в хронологическом порядке:
+//	ASSIGNED, UNASSIGNED, REASSIGNED (previous_reviewer_id — кого заменили), MERGED, CLOSED, REOPENED,
+//	REVIEWED (reviewer_id отправил вердикт, reason — вердикт).
+//	actor_id — значение заголовка X-Actor-ID запроса, изменившего назначения.
+//
+// @Tags PullRequests
+// @Produce json
+// @Param pull_request_id query string true "Идентификатор PR"
+// @Success 200 {object} HistoryResponse "История назначений"
+// @Failure 400 {object} response.ErrorResponse "MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /pullRequest/history [get]
+func (handler *PullRequestHandler) History(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	prID := r.URL.Query().Get("pull_request_id")
+	if prID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "pull_request_id field is required")
+		return
+	}
+
+	events, err := handler.prService.History(r.Context(), prID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPRNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+		}
+		return
+	}
+
+	historyResponse := HistoryResponse{
+		PullRequestID: prID,
+		Events:        make([]AssignmentEventResponse, 0, len(events)),
+	}
+	for _, event := range events {
+		historyResponse.Events = append(historyResponse.Events, AssignmentEventResponse{
+			ID:                 event.ID,
+			EventType:          string(event.Type),
+			ReviewerID:         event.ReviewerID,
+			PreviousReviewerID: event.PreviousReviewerID,
+			ActorID:            event.ActorID,
+			Reason:             event.Reason,
+			CreatedAt:          event.CreatedAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, historyResponse)
+}
+
+// toDetailsResponse переводит PR с ревьюверами в формат ответа.
+func toDetailsResponse(pr *domain.PullRequestDetails) PullRequestDetailsResponse {
+	prResponse := PullRequestDetailsResponse{
+		PullRequestID:     pr.PullRequestID,
+		PullRequestName:   pr.PullRequestName,
+		AuthorID:          pr.AuthorID,
+		Status:            string(pr.Status),
+		Reviewers:         make([]ReviewerResponse, 0, len(pr.Reviewers)),
+		RequiredReviewers: pr.RequiredReviewers,
+		Tags:              pr.Tags,
+		Version:           pr.Version,
+		CreatedAt:         pr.CreatedAt,
+		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+	}
+	for _, reviewer := range pr.Reviewers {
+		prResponse.Reviewers = append(prResponse.Reviewers, ReviewerResponse{
+			UserID:     reviewer.UserID,
+			AssignedAt: reviewer.AssignedAt,
+			State:      string(reviewer.State),
+			ReviewedAt: reviewer.ReviewedAt,
+		})
+	}
+	return prResponse
+}
+
+// toFallbackReviewers переводит ревьюверов из резервных команд в формат ответа.
+func toFallbackReviewers(reviewers []domain.FallbackReviewer) []FallbackReviewer {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	result := make([]FallbackReviewer, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		result = append(result, FallbackReviewer{UserID: reviewer.UserID, TeamName: reviewer.TeamName})
+	}
+	return result
+}
+
+func toCodeOwnerReviewers(reviewers []domain.CodeOwnerReviewer) []CodeOwnerReviewer {
+	if len(reviewers) == 0 {
+		return nil
+	}
+	result := make([]CodeOwnerReviewer, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		result = append(result, CodeOwnerReviewer{UserID: reviewer.UserID, Pattern: reviewer.Pattern})
+	}
+	return result
+}
+
+func toRuleEffects(effects []domain.RuleEffect) []RuleEffect {
+	if len(effects) == 0 {
+		return nil
+	}
+	result := make([]RuleEffect, 0, len(effects))
+	for _, effect := range effects {
+		result = append(result, RuleEffect{
+			RuleID: effect.RuleID,
+			Type:   string(effect.Type),
+			UserID: effect.UserID,
+			Effect: string(effect.Effect),
+		})
+	}
+	return result
+}
+
+// setETag отдаёт версию PR в заголовке ETag, чтобы клиент мог вернуть её в If-Match.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
+// parseETag разбирает значение If-Match вида "3", W/"3" или 3.
+func parseETag(value string) (int64, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "W/")
+	return strconv.ParseInt(strings.Trim(value, `"`), 10, 64)
+}
@@ -1,14 +1,41 @@
 package teams
 
+import "time"
+
 type Member struct {
 	UserID   string `json:"user_id"`
 	Username string `json:"username"`
 	IsActive bool   `json:"is_active"`
+	// Load - нагрузка по метрике команды, заполняется только в ответе /team/get.
+	Load *float64 `json:"load,omitempty"`
+	// MaxOpenReviews - лимит открытых ревью; в запросе не передан - без изменений, 0 - снять лимит.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// Tags - области экспертизы; в запросе не передано - без изменений, [] - очистить.
+	Tags []string `json:"tags,omitempty"`
 }
 
 type TeamAddRequest struct {
-	TeamName string   `json:"team_name"`
-	Members  []Member `json:"members"`
+	TeamName          string   `json:"team_name"`
+	Members           []Member `json:"members"`
+	RequiredReviewers int      `json:"required_reviewers,omitempty"`
+	SelectionStrategy string   `json:"selection_strategy,omitempty"`
+	LoadMetric        string   `json:"load_metric,omitempty"`
+	LoadWindowHours   int      `json:"load_window_hours,omitempty"`
+	LoadHalfLifeHours int      `json:"load_half_life_hours,omitempty"`
+	// FallbackTeams - резервные команды по приоритету; не передано - без изменений, [] - очистить.
+	FallbackTeams []string `json:"fallback_teams,omitempty"`
+	// RequiredApprovals - одобрений для merge; не передано - без изменений, 0 - без проверки.
+	RequiredApprovals *int `json:"required_approvals,omitempty"`
+	// ReviewSLAHours - SLA ревью в рабочих часах; не передано - без изменений, 0 - снять SLA.
+	ReviewSLAHours *int `json:"review_sla_hours,omitempty"`
+	// AutoReassignHours - порог автоматического переназначения в рабочих часах; не передано - без изменений, 0 - отключить.
+	AutoReassignHours *int `json:"auto_reassign_hours,omitempty"`
+	// BusinessDayStart, BusinessDayEnd - часы начала и конца рабочего дня (0..24, по умолчанию 9 и 18);
+	// не передано - без изменений.
+	BusinessDayStart *int `json:"business_day_start,omitempty"`
+	BusinessDayEnd   *int `json:"business_day_end,omitempty"`
+	// BusinessTimeZone - часовой пояс рабочего дня (IANA, по умолчанию UTC); не передано - без изменений.
+	BusinessTimeZone string `json:"business_time_zone,omitempty"`
 }
 
 type TeamAddResponse struct {
@@ -16,6 +43,101 @@ type TeamAddResponse struct {
 }
 
 type TeamResponse struct {
+	TeamName          string   `json:"team_name"`
+	Members           []Member `json:"members"`
+	RequiredReviewers int      `json:"required_reviewers"`
+	SelectionStrategy string   `json:"selection_strategy"`
+	LoadMetric        string   `json:"load_metric"`
+	LoadWindowHours   int      `json:"load_window_hours"`
+	LoadHalfLifeHours int      `json:"load_half_life_hours"`
+	FallbackTeams     []string `json:"fallback_teams"`
+	RequiredApprovals int      `json:"required_approvals"`
+	ReviewSLAHours    *int     `json:"review_sla_hours,omitempty"`
+	AutoReassignHours *int     `json:"auto_reassign_hours,omitempty"`
+	BusinessDayStart  int      `json:"business_day_start"`
+	BusinessDayEnd    int      `json:"business_day_end"`
+	BusinessTimeZone  string   `json:"business_time_zone"`
+}
+
+type DeactivateUsersRequest struct {
 	TeamName string   `json:"team_name"`
-	Members  []Member `json:"members"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type ReassignmentResponse struct {
+	PullRequestID  string  `json:"pull_request_id"`
+	ReplacedUserID string  `json:"replaced_user_id"`
+	Status         string  `json:"status"`
+	NewReviewerID  *string `json:"new_reviewer_id,omitempty"`
+}
+
+type DeactivateUsersResponse struct {
+	TeamName           string                 `json:"team_name"`
+	DeactivatedUserIDs []string               `json:"deactivated_user_ids"`
+	Reassignments      []ReassignmentResponse `json:"reassignments"`
+}
+
+// CodeOwnerRule - правило владения путями: задаётся ровно одно из owner_user_id и owner_team.
+type CodeOwnerRule struct {
+	Pattern     string  `json:"pattern"`
+	OwnerUserID *string `json:"owner_user_id,omitempty"`
+	OwnerTeam   *string `json:"owner_team,omitempty"`
+}
+
+type SetCodeOwnersRequest struct {
+	TeamName string          `json:"team_name"`
+	Rules    []CodeOwnerRule `json:"rules"`
+}
+
+type CodeOwnersResponse struct {
+	TeamName string          `json:"team_name"`
+	Rules    []CodeOwnerRule `json:"rules"`
+}
+
+type AddRuleRequest struct {
+	TeamName string `json:"team_name"`
+	// Type - NEVER_REVIEWS (нужен reviewer_id) или REQUIRES_TAG (нужен tag).
+	Type       string  `json:"type"`
+	AuthorID   string  `json:"author_id"`
+	ReviewerID *string `json:"reviewer_id,omitempty"`
+	Tag        *string `json:"tag,omitempty"`
+}
+
+type RuleResponse struct {
+	RuleID     int64     `json:"rule_id"`
+	TeamName   string    `json:"team_name"`
+	Type       string    `json:"type"`
+	AuthorID   string    `json:"author_id"`
+	ReviewerID *string   `json:"reviewer_id,omitempty"`
+	Tag        *string   `json:"tag,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AddRuleResponse struct {
+	Rule RuleResponse `json:"rule"`
+}
+
+type GetRulesResponse struct {
+	TeamName string         `json:"team_name"`
+	Rules    []RuleResponse `json:"rules"`
+}
+
+type DeleteRuleRequest struct {
+	TeamName string `json:"team_name"`
+	RuleID   int64  `json:"rule_id"`
+}
+
+// OverdueReviewResponse - назначение участника команды, по которому истёк SLA ревью.
+type OverdueReviewResponse struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	ReviewerID      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	DueAt           time.Time `json:"due_at"`
+}
+
+type OverdueReviewsResponse struct {
+	TeamName string                  `json:"team_name"`
+	Reviews  []OverdueReviewResponse `json:"reviews"`
 }
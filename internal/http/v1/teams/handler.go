@@ -11,10 +11,14 @@ import (
 
 type TeamsHandler struct {
 	teamService *service.TeamService
+	prService   *service.PullRequestService
 }
 
-func NewTeamsHandler(teamService *service.TeamService) *TeamsHandler {
-	return &TeamsHandler{teamService: teamService}
+func NewTeamsHandler(teamService *service.TeamService, prService *service.PullRequestService) *TeamsHandler {
+	return &TeamsHandler{
+		teamService: teamService,
+		prService:   prService,
+	}
 }
 
 // Add godoc
@@ -22,14 +26,34 @@ func NewTeamsHandler(teamService *service.TeamService) *TeamsHandler {
 // @Description
 //   - Если команды ещё нет — создаётся команда и все участники добавляются в team_members.
 //   - Если команда уже есть — обновляются участники (добавляются/удаляются) и флаг is_active у пользователей.
+//     Ревью деактивированных участников в открытых PR переназначаются на других.
 //   - Если пользователь уже состоит в другой команде — вернётся ошибка.
+//   - required_reviewers задаёт число ревьюверов на PR (1..10, по умолчанию 2).
+//   - selection_strategy задаёт стратегию выбора ревьюверов: LEAST_LOADED (по умолчанию), ROUND_ROBIN,
+//     WEIGHTED_RANDOM, LEAST_OPEN_REVIEWS. Для существующей команды пустое значение оставляет текущую.
+//   - load_metric задаёт метрику нагрузки ревьювера: OPEN (по умолчанию), ALL_TIME, WINDOW
+//     (за load_window_hours часов), DECAY (вес назначения уменьшается вдвое каждые load_half_life_hours часов).
+//   - members[].max_open_reviews задаёт лимит открытых ревью участника (0 снимает лимит, не переданное поле
+//     оставляет прежний). Участник, достигший лимита, не выбирается ревьювером.
+//   - members[].tags задаёт теги экспертизы участника (не переданное поле оставляет прежние, [] очищает).
+//   - fallback_teams задаёт резервные команды в порядке приоритета: из их активных участников добираются
+//     ревьюверы, если в команде не хватило кандидатов. Не переданное поле оставляет текущий список, [] очищает его.
+//   - required_approvals задаёт, сколько одобрений (/pullRequest/review) нужно PR автора команды для merge
+//     (0..10, 0 — merge без проверки). Не переданное поле оставляет текущее значение.
+//   - review_sla_hours задаёт SLA ревью в рабочих часах команды: назначение без вердикта
+//     дольше этого срока считается просроченным. 0 снимает SLA, не переданное поле оставляет текущее значение.
+//   - auto_reassign_hours задаёт, через сколько рабочих часов без вердикта ревью участника команды
+//     автоматически переназначается фоновым планировщиком. 0 отключает, не переданное поле оставляет текущее значение.
+//   - business_day_start, business_day_end и business_time_zone задают рабочий день команды для review_sla_hours
+//     и auto_reassign_hours: будни с business_day_start до business_day_end часов (0..24) в часовом поясе IANA
+//     (по умолчанию 9, 18, UTC). Не переданные поля оставляют текущие значения.
 //
 // @Tags Teams
 // @Accept json
 // @Produce json
 // @Param request body TeamAddRequest true "Команда и её участники"
 // @Success 201 {object} TeamAddResponse "Созданная/обновлённая команда"
-// @Failure 400 {object} response.ErrorResponse "INVALID_JSON"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_REVIEWERS_COUNT / INVALID_STRATEGY / INVALID_LOAD_METRIC / INVALID_FALLBACK_TEAM / INVALID_CAPACITY / INVALID_TAG / INVALID_REQUIRED_APPROVALS / INVALID_REVIEW_SLA / INVALID_AUTO_REASSIGN / INVALID_BUSINESS_HOURS"
 // @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
 // @Failure 409 {object} response.ErrorResponse "USERS_TEAM_EXISTS"
 // @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
@@ -49,13 +73,29 @@ func (handler *TeamsHandler) Add(w http.ResponseWriter, r *http.Request) {
 
 	teamDomain := domain.Team{
 		TeamName: request.TeamName,
+		TeamSettings: domain.TeamSettings{
+			RequiredReviewers: request.RequiredReviewers,
+			SelectionStrategy: domain.SelectionStrategy(request.SelectionStrategy),
+			LoadMetric:        domain.LoadMetric(request.LoadMetric),
+			LoadWindowHours:   request.LoadWindowHours,
+			LoadHalfLifeHours: request.LoadHalfLifeHours,
+			FallbackTeams:     request.FallbackTeams,
+			RequiredApprovals: request.RequiredApprovals,
+			ReviewSLAHours:    request.ReviewSLAHours,
+			AutoReassignHours: request.AutoReassignHours,
+			BusinessDayStart:  request.BusinessDayStart,
+			BusinessDayEnd:    request.BusinessDayEnd,
+			BusinessTimeZone:  request.BusinessTimeZone,
+		},
 	}
 
 	for _, member := range request.Members {
 		teamDomain.Members = append(teamDomain.Members, domain.Member{
-			Username: member.Username,
-			UserID:   member.UserID,
-			IsActive: member.IsActive,
+			Username:       member.Username,
+			UserID:         member.UserID,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: member.MaxOpenReviews,
+			Tags:           member.Tags,
 		})
 	}
 
@@ -70,6 +110,26 @@ func (handler *TeamsHandler) Add(w http.ResponseWriter, r *http.Request) {
 			response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
 		case errors.Is(err, domain.ErrUserAlreadyInTeam):
 			response.Error(w, http.StatusConflict, "TEAMS_CONFLICT", err.Error())
+		case errors.Is(err, domain.ErrInvalidReviewersCount):
+			response.Error(w, http.StatusBadRequest, "INVALID_REVIEWERS_COUNT", err.Error())
+		case errors.Is(err, domain.ErrUnknownSelectionStrategy):
+			response.Error(w, http.StatusBadRequest, "INVALID_STRATEGY", err.Error())
+		case errors.Is(err, domain.ErrUnknownLoadMetric):
+			response.Error(w, http.StatusBadRequest, "INVALID_LOAD_METRIC", err.Error())
+		case errors.Is(err, domain.ErrInvalidCapacity):
+			response.Error(w, http.StatusBadRequest, "INVALID_CAPACITY", err.Error())
+		case errors.Is(err, domain.ErrInvalidTag):
+			response.Error(w, http.StatusBadRequest, "INVALID_TAG", err.Error())
+		case errors.Is(err, domain.ErrInvalidFallbackTeam):
+			response.Error(w, http.StatusBadRequest, "INVALID_FALLBACK_TEAM", err.Error())
+		case errors.Is(err, domain.ErrInvalidRequiredApprovals):
+			response.Error(w, http.StatusBadRequest, "INVALID_REQUIRED_APPROVALS", err.Error())
+		case errors.Is(err, domain.ErrInvalidReviewSLA):
+			response.Error(w, http.StatusBadRequest, "INVALID_REVIEW_SLA", err.Error())
+		case errors.Is(err, domain.ErrInvalidAutoReassign):
+			response.Error(w, http.StatusBadRequest, "INVALID_AUTO_REASSIGN", err.Error())
+		case errors.Is(err, domain.ErrInvalidBusinessHours):
+			response.Error(w, http.StatusBadRequest, "INVALID_BUSINESS_HOURS", err.Error())
 		default:
 			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
 		}
@@ -78,11 +138,24 @@ func (handler *TeamsHandler) Add(w http.ResponseWriter, r *http.Request) {
 
 	var teamResponse TeamAddResponse
 	teamResponse.Team.TeamName = team.TeamName
+	teamResponse.Team.RequiredReviewers = team.RequiredReviewers
+	teamResponse.Team.SelectionStrategy = string(team.SelectionStrategy)
+	teamResponse.Team.LoadMetric = string(team.LoadMetric)
+	teamResponse.Team.LoadWindowHours = team.LoadWindowHours
+	teamResponse.Team.LoadHalfLifeHours = team.LoadHalfLifeHours
+	teamResponse.Team.FallbackTeams = fallbackTeams(team.FallbackTeams)
+	teamResponse.Team.RequiredApprovals = requiredApprovals(team.RequiredApprovals)
+	teamResponse.Team.ReviewSLAHours = team.ReviewSLAHours
+	teamResponse.Team.AutoReassignHours = team.AutoReassignHours
+	teamResponse.Team.BusinessDayStart, teamResponse.Team.BusinessDayEnd, teamResponse.Team.BusinessTimeZone =
+		businessDay(team.TeamSettings)
 	for _, member := range team.Members {
 		teamResponse.Team.Members = append(teamResponse.Team.Members, Member{
-			Username: member.Username,
-			UserID:   member.UserID,
-			IsActive: member.IsActive,
+			Username:       member.Username,
+			UserID:         member.UserID,
+			IsActive:       member.IsActive,
+			MaxOpenReviews: member.MaxOpenReviews,
+			Tags:           member.Tags,
 		})
 	}
 
@@ -91,7 +164,7 @@ func (handler *TeamsHandler) Add(w http.ResponseWriter, r *http.Request) {
 
 // Get godoc
 // @Summary Получить команду с участниками
-// @Description Возвращает состав команды по её имени.
+// @Description Возвращает состав команды по её имени, настройки назначения и нагрузку участников (load) по метрике команды.
 // @Tags Teams
 // @Accept json
 // @Produce json
@@ -127,14 +200,456 @@ func (handler *TeamsHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	var teamResponse TeamResponse
 	teamResponse.TeamName = teamName
+	teamResponse.RequiredReviewers = teamDomain.RequiredReviewers
+	teamResponse.SelectionStrategy = string(teamDomain.SelectionStrategy)
+	teamResponse.LoadMetric = string(teamDomain.LoadMetric)
+	teamResponse.LoadWindowHours = teamDomain.LoadWindowHours
+	teamResponse.LoadHalfLifeHours = teamDomain.LoadHalfLifeHours
+	teamResponse.FallbackTeams = fallbackTeams(teamDomain.FallbackTeams)
+	teamResponse.RequiredApprovals = requiredApprovals(teamDomain.RequiredApprovals)
+	teamResponse.ReviewSLAHours = teamDomain.ReviewSLAHours
+	teamResponse.AutoReassignHours = teamDomain.AutoReassignHours
+	teamResponse.BusinessDayStart, teamResponse.BusinessDayEnd, teamResponse.BusinessTimeZone =
+		businessDay(teamDomain.TeamSettings)
 
 	for _, member := range teamDomain.Members {
 		teamResponse.Members = append(teamResponse.Members, Member{
-			Username: member.Username,
-			UserID:   member.UserID,
-			IsActive: member.IsActive,
+			Username:       member.Username,
+			UserID:         member.UserID,
+			IsActive:       member.IsActive,
+			Load:           member.Load,
+			MaxOpenReviews: member.MaxOpenReviews,
+			Tags:           member.Tags,
 		})
 	}
 
 	response.JSON(w, http.StatusOK, teamResponse)
 }
+
+// DeactivateUsers godoc
+// @Summary Деактивировать участников команды с переназначением их ревью
+// @Description
+//   - В одной транзакции деактивирует пользователей user_ids команды team_name.
+//   - Их ревью в открытых PR переназначаются на оставшихся активных участников команды автора
+//     (и её резервных команд) по стратегии команды; деактивируемые не получают ни одного ревью.
+//   - Нагрузка кандидатов читается один раз на команду и дальше учитывается в памяти.
+//   - В reassignments возвращается итог по каждому PR: REASSIGNED или NO_CANDIDATE (ревьювер остался в PR).
+//   - Если кто-то из user_ids не состоит в команде, ничего не меняется.
+//
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body DeactivateUsersRequest true "Команда и деактивируемые участники"
+// @Success 200 {object} DeactivateUsersResponse "Деактивированные участники и итоги переназначения"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND / NOT_IN_TEAM"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/deactivateUsers [post]
+func (handler *TeamsHandler) DeactivateUsers(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request DeactivateUsersRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.TeamName == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name field is required")
+		return
+	}
+	if len(request.UserIDs) == 0 {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "user_ids field is required")
+		return
+	}
+
+	deactivated, outcomes, err := handler.teamService.DeactivateUsers(r.Context(), request.TeamName, request.UserIDs)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTeamNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		case errors.Is(err, domain.ErrUserNotInTeam):
+			response.Error(w, http.StatusNotFound, "NOT_IN_TEAM", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	deactivateResponse := DeactivateUsersResponse{
+		TeamName:           request.TeamName,
+		DeactivatedUserIDs: deactivated,
+		Reassignments:      make([]ReassignmentResponse, 0, len(outcomes)),
+	}
+	for _, outcome := range outcomes {
+		deactivateResponse.Reassignments = append(deactivateResponse.Reassignments, ReassignmentResponse{
+			PullRequestID:  outcome.PullRequestID,
+			ReplacedUserID: outcome.ReplacedUserID,
+			Status:         string(outcome.Status),
+			NewReviewerID:  outcome.NewReviewerID,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, deactivateResponse)
+}
+
+// SetCodeOwners godoc
+// @Summary Задать правила владения путями (CODEOWNERS) команды
+// @Description
+//   - Заменяет все правила команды team_name на rules; порядок правил сохраняется.
+//   - pattern - glob по сегментам пути: "*" и "?" не выходят за "/", "**" - любое число каталогов.
+//     Шаблон без "/" ищется в любом каталоге, с "/" - от корня, "/" в конце означает содержимое каталога.
+//   - Владелец - пользователь (owner_user_id) или команда (owner_team), ровно одно из двух.
+//   - При /pullRequest/create с changed_files для каждого файла действует последнее подходящее правило,
+//     как в CODEOWNERS; владельцы назначаются раньше остальных ревьюверов. От команды-владельца
+//     назначается один участник по стратегии команды автора.
+//   - rules: [] удаляет все правила.
+//
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body SetCodeOwnersRequest true "Команда и правила"
+// @Success 200 {object} CodeOwnersResponse "Сохранённые правила"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / MISSING_FIELD / INVALID_CODE_OWNER_RULE"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND (команда или владелец не найдены)"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/setCodeOwners [post]
+func (handler *TeamsHandler) SetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request SetCodeOwnersRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.TeamName == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name field is required")
+		return
+	}
+
+	rules := make([]domain.CodeOwnerRule, 0, len(request.Rules))
+	for _, rule := range request.Rules {
+		rules = append(rules, domain.CodeOwnerRule{
+			Pattern:     rule.Pattern,
+			OwnerUserID: rule.OwnerUserID,
+			OwnerTeam:   rule.OwnerTeam,
+		})
+	}
+
+	saved, err := handler.teamService.SetCodeOwners(r.Context(), request.TeamName, rules)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCodeOwnerRule):
+			response.Error(w, http.StatusBadRequest, "INVALID_CODE_OWNER_RULE", err.Error())
+		case errors.Is(err, domain.ErrTeamNotFound), errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCodeOwnersResponse(request.TeamName, saved))
+}
+
+// GetCodeOwners godoc
+// @Summary Получить правила владения путями команды
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param team_name query string true "Уникальное имя команды"
+// @Success 200 {object} CodeOwnersResponse "Правила команды в порядке задания"
+// @Failure 400 {object} response.ErrorResponse "MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/getCodeOwners [get]
+func (handler *TeamsHandler) GetCodeOwners(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name field is required")
+		return
+	}
+
+	rules, err := handler.teamService.GetCodeOwners(r.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTeamNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, toCodeOwnersResponse(teamName, rules))
+}
+
+func toCodeOwnersResponse(teamName string, rules []domain.CodeOwnerRule) CodeOwnersResponse {
+	codeOwnersResponse := CodeOwnersResponse{
+		TeamName: teamName,
+		Rules:    make([]CodeOwnerRule, 0, len(rules)),
+	}
+	for _, rule := range rules {
+		codeOwnersResponse.Rules = append(codeOwnersResponse.Rules, CodeOwnerRule{
+			Pattern:     rule.Pattern,
+			OwnerUserID: rule.OwnerUserID,
+			OwnerTeam:   rule.OwnerTeam,
+		})
+	}
+	return codeOwnersResponse
+}
+
+// AddRule godoc
+// @Summary Добавить правило подбора ревьюверов для автора
+// @Description
+//   - NEVER_REVIEWS: reviewer_id никогда не ревьюит PR автора author_id (конфликты, разделение менторства).
+//   - REQUIRES_TAG: у PR автора author_id всегда есть хотя бы один ревьювер с тегом tag (например, senior).
+//   - Правила применяются при create и reassign до балансировки нагрузки, их влияние на выбор
+//     возвращается в rule_effects ответа.
+//   - Автор должен состоять в команде team_name.
+//
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body AddRuleRequest true "Правило"
+// @Success 201 {object} AddRuleResponse "Сохранённое правило"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / MISSING_FIELD / INVALID_RULE"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND / NOT_IN_TEAM"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/addRule [post]
+func (handler *TeamsHandler) AddRule(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request AddRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.TeamName == "" || request.AuthorID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name and author_id fields are required")
+		return
+	}
+
+	rule, err := handler.teamService.AddReviewerRule(r.Context(), domain.ReviewerRule{
+		TeamName:   request.TeamName,
+		Type:       domain.ReviewerRuleType(request.Type),
+		AuthorID:   request.AuthorID,
+		ReviewerID: request.ReviewerID,
+		Tag:        request.Tag,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReviewerRule):
+			response.Error(w, http.StatusBadRequest, "INVALID_RULE", err.Error())
+		case errors.Is(err, domain.ErrUserNotInTeam):
+			response.Error(w, http.StatusNotFound, "NOT_IN_TEAM", err.Error())
+		case errors.Is(err, domain.ErrTeamNotFound), errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", err.Error())
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, AddRuleResponse{Rule: toRuleResponse(rule)})
+}
+
+// GetRules godoc
+// @Summary Получить правила подбора ревьюверов команды
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param team_name query string true "Уникальное имя команды"
+// @Success 200 {object} GetRulesResponse "Правила команды в порядке добавления"
+// @Failure 400 {object} response.ErrorResponse "MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/getRules [get]
+func (handler *TeamsHandler) GetRules(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name field is required")
+		return
+	}
+
+	rules, err := handler.teamService.ListReviewerRules(r.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTeamNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	rulesResponse := GetRulesResponse{
+		TeamName: teamName,
+		Rules:    make([]RuleResponse, 0, len(rules)),
+	}
+	for i := range rules {
+		rulesResponse.Rules = append(rulesResponse.Rules, toRuleResponse(&rules[i]))
+	}
+
+	response.JSON(w, http.StatusOK, rulesResponse)
+}
+
+// DeleteRule godoc
+// @Summary Удалить правило подбора ревьюверов
+// @Tags Teams
+// @Accept json
+// @Produce json
+// @Param request body DeleteRuleRequest true "Команда и правило"
+// @Success 204 "Правило удалено"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "Правило не найдено"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/deleteRule [post]
+func (handler *TeamsHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request DeleteRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.TeamName == "" || request.RuleID == 0 {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name and rule_id fields are required")
+		return
+	}
+
+	err := handler.teamService.DeleteReviewerRule(r.Context(), request.TeamName, request.RuleID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrReviewerRuleNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toRuleResponse(rule *domain.ReviewerRule) RuleResponse {
+	return RuleResponse{
+		RuleID:     rule.ID,
+		TeamName:   rule.TeamName,
+		Type:       string(rule.Type),
+		AuthorID:   rule.AuthorID,
+		ReviewerID: rule.ReviewerID,
+		Tag:        rule.Tag,
+		CreatedAt:  rule.CreatedAt,
+	}
+}
+
+// OverdueReviews godoc
+// @Summary Получить просроченные ревью участников команды
+// @Description
+//
+//	Возвращает назначения участников команды в открытых PR без вердикта, по которым истёк SLA команды
+//	(review_sla_hours рабочих часов с момента назначения по рабочему дню команды, по умолчанию будни 09:00–18:00 UTC).
+//	Если у команды нет SLA, список пуст.
+//
+// @Tags Teams
+// @Produce json
+// @Param team_name query string true "Имя команды"
+// @Success 200 {object} OverdueReviewsResponse "Просроченные ревью, от самых старых"
+// @Failure 400 {object} response.ErrorResponse "MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "NOT_FOUND"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /team/overdueReviews [get]
+func (handler *TeamsHandler) OverdueReviews(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "team_name field is required")
+		return
+	}
+
+	reviews, err := handler.prService.OverdueReviewsByTeam(r.Context(), teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTeamNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	overdueResponse := OverdueReviewsResponse{
+		TeamName: teamName,
+		Reviews:  make([]OverdueReviewResponse, 0, len(reviews)),
+	}
+	for _, review := range reviews {
+		overdueResponse.Reviews = append(overdueResponse.Reviews, OverdueReviewResponse{
+			PullRequestID:   review.PullRequestID,
+			PullRequestName: review.PullRequestName,
+			AuthorID:        review.AuthorID,
+			ReviewerID:      review.ReviewerID,
+			AssignedAt:      review.AssignedAt,
+			DueAt:           *review.DueAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, overdueResponse)
+}
+
+// fallbackTeams отдаёт пустой массив вместо null, если резервных команд нет.
+func fallbackTeams(teams []string) []string {
+	if teams == nil {
+		return []string{}
+	}
+	return teams
+}
+
+func requiredApprovals(approvals *int) int {
+	if approvals == nil {
+		return 0
+	}
+	return *approvals
+}
+
+// businessDay возвращает рабочий день команды для ответа; незаданные поля - значения по умолчанию.
+func businessDay(settings domain.TeamSettings) (int, int, string) {
+	settings = settings.WithDefaults()
+	return *settings.BusinessDayStart, *settings.BusinessDayEnd, settings.BusinessTimeZone
+}
@@ -1,5 +1,7 @@
 package users
 
+import "time"
+
 type SetActiveRequest struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
@@ -10,10 +12,36 @@ type UserResponse struct {
 	Username string  `json:"username"`
 	TeamName *string `json:"team_name,omitempty"`
 	IsActive bool    `json:"is_active"`
+	// MaxOpenReviews - лимит открытых ревью, отсутствует, если лимит не задан.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// Tags - области экспертизы пользователя.
+	Tags []string `json:"tags,omitempty"`
+}
+
+type UpdateUserRequest struct {
+	UserID   string `json:"user_id"`
+	Username string `json:"username,omitempty"`
+	// MaxOpenReviews - лимит открытых ревью: не передан - без изменений, 0 - снять лимит.
+	MaxOpenReviews *int `json:"max_open_reviews,omitempty"`
+	// Tags - области экспертизы: не передано - без изменений, [] - очистить.
+	Tags []string `json:"tags,omitempty"`
+}
+
+type UpdateUserResponse struct {
+	User UserResponse `json:"user"`
 }
 
 type SetIsActiveResponse struct {
 	User UserResponse `json:"user"`
+	// Reassignments - отчёт о переназначении открытых ревью, заполняется при деактивации.
+	Reassignments []ReassignmentResponse `json:"reassignments,omitempty"`
+}
+
+type ReassignmentResponse struct {
+	PullRequestID  string  `json:"pull_request_id"`
+	ReplacedUserID string  `json:"replaced_user_id"`
+	Status         string  `json:"status"`
+	NewReviewerID  *string `json:"new_reviewer_id,omitempty"`
 }
 
 type PullRequestResponse struct {
@@ -21,9 +49,56 @@ type PullRequestResponse struct {
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
 	Status          string `json:"status"`
+	// Overdue - по ревью истёк SLA команды пользователя.
+	Overdue bool       `json:"overdue"`
+	DueAt   *time.Time `json:"due_at,omitempty"`
 }
 
 type GetReviewResponse struct {
 	UserID       string                `json:"user_id"`
 	PullRequests []PullRequestResponse `json:"pull_requests"`
 }
+
+type AddUnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+type UnavailabilityResponse struct {
+	ID       int64     `json:"unavailability_id"`
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type AddUnavailabilityResponse struct {
+	Unavailability UnavailabilityResponse `json:"unavailability"`
+}
+
+type GetUnavailabilityResponse struct {
+	UserID         string                   `json:"user_id"`
+	Unavailability []UnavailabilityResponse `json:"unavailability"`
+}
+
+type DeleteUnavailabilityRequest struct {
+	UserID           string `json:"user_id"`
+	UnavailabilityID int64  `json:"unavailability_id"`
+}
+
+// OverdueReviewResponse - назначение, по которому истёк SLA ревью.
+type OverdueReviewResponse struct {
+	PullRequestID   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	AuthorID        string    `json:"author_id"`
+	ReviewerID      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	DueAt           time.Time `json:"due_at"`
+}
+
+type OverdueReviewsResponse struct {
+	UserID  string                  `json:"user_id"`
+	Reviews []OverdueReviewResponse `json:"reviews"`
+}
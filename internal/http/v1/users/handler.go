@@ -23,7 +23,11 @@ func NewUsersHandler(userService *service.UserService, prService *service.PullRe
 
 // SetIsActive
 // @Summary      Установить флаг активности пользователя
-// @Description  Принимает user_id и is_active, обновляет пользователя и возвращает его состояние
+// @Description  Принимает user_id и is_active, обновляет пользователя и возвращает его состояние.
+// @Description  При деактивации все ревью пользователя в открытых PR в той же транзакции переназначаются
+// @Description  на других активных участников команды автора (как в /pullRequest/reassign).
+// @Description  В reassignments возвращается итог по каждому PR: REASSIGNED (new_reviewer_id — замена)
+// @Description  или NO_CANDIDATE (заменить некем, ревьювер остался в PR).
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -52,7 +56,7 @@ func (handler *UsersHandler) SetIsActive(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	user, err := handler.userService.SetIsActive(r.Context(), request.UserID, request.IsActive)
+	user, outcomes, err := handler.userService.SetIsActive(r.Context(), request.UserID, request.IsActive)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrUserNotFound):
@@ -65,20 +69,77 @@ func (handler *UsersHandler) SetIsActive(w http.ResponseWriter, r *http.Request)
 	}
 
 	resp := SetIsActiveResponse{
-		User: UserResponse{
-			UserID:   user.ID,
-			Username: user.Username,
-			TeamName: user.TeamName,
-			IsActive: user.IsActive,
-		},
+		User: toUserResponse(user),
+	}
+	for _, outcome := range outcomes {
+		resp.Reassignments = append(resp.Reassignments, ReassignmentResponse{
+			PullRequestID:  outcome.PullRequestID,
+			ReplacedUserID: outcome.ReplacedUserID,
+			Status:         string(outcome.Status),
+			NewReviewerID:  outcome.NewReviewerID,
+		})
 	}
 
 	response.JSON(w, http.StatusOK, resp)
 }
 
+// Update
+// @Summary      Обновить пользователя
+// @Description  Меняет имя, лимит открытых ревью (max_open_reviews) и теги экспертизы (tags) пользователя.
+// @Description  Не переданные поля не меняются, max_open_reviews = 0 снимает лимит, tags = [] очищает теги.
+// @Description  Пользователь, у которого открытых ревью не меньше лимита, не выбирается ревьювером;
+// @Description  уже назначенные ревью при снижении лимита не снимаются.
+// @Description  Теги приводятся к нижнему регистру; PR с совпадающими тегами назначаются таким пользователям в первую очередь.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      UpdateUserRequest       true  "Тело запроса"
+// @Success      200      {object}  UpdateUserResponse      "Обновлённый пользователь"
+// @Failure      400      {object}  response.ErrorResponse  "INVALID_JSON / MISSING_FIELD / INVALID_CAPACITY / INVALID_TAG"
+// @Failure      404      {object}  response.ErrorResponse  "Пользователь не найден"
+// @Failure      500      {object}  response.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /users/update [post]
+func (handler *UsersHandler) Update(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request UpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "user_id field is required")
+		return
+	}
+
+	user, err := handler.userService.Update(r.Context(), request.UserID, request.Username, request.MaxOpenReviews, request.Tags)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCapacity):
+			response.Error(w, http.StatusBadRequest, "INVALID_CAPACITY", err.Error())
+		case errors.Is(err, domain.ErrInvalidTag):
+			response.Error(w, http.StatusBadRequest, "INVALID_TAG", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusOK, UpdateUserResponse{User: toUserResponse(user)})
+}
+
 // GetReview
 // @Summary      Получить PR'ы, где пользователь назначен ревьювером
-// @Description  Возвращает список PR'ов, в которых user_id указан как ревьювер
+// @Description  Возвращает список PR'ов, в которых user_id указан как ревьювер (закрытые PR не включаются).
+// @Description  Если у команды пользователя задан review_sla_hours, для каждого PR возвращается срок due_at,
+// @Description  а overdue = true, если PR открыт, вердикта ещё нет и срок прошёл.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -116,6 +177,8 @@ func (handler *UsersHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 			PullRequestName: pr.PullRequestName,
 			AuthorID:        pr.AuthorID,
 			Status:          string(pr.Status),
+			Overdue:         pr.Overdue,
+			DueAt:           pr.DueAt,
 		}
 
 		reviewResponse.PullRequests = append(reviewResponse.PullRequests, prResponse)
@@ -123,3 +186,216 @@ func (handler *UsersHandler) GetReview(w http.ResponseWriter, r *http.Request) {
 
 	response.JSON(w, http.StatusOK, reviewResponse)
 }
+
+// OverdueReviews
+// @Summary      Получить просроченные ревью пользователя
+// @Description  Возвращает назначения user_id в открытых PR без вердикта, по которым истёк SLA его команды
+// @Description  (review_sla_hours рабочих часов с момента назначения по рабочему дню команды, по умолчанию будни 09:00–18:00 UTC).
+// @Description  Если у команды нет SLA, список пуст.
+// @Tags         Users
+// @Produce      json
+// @Param        user_id  query     string                  true  "Идентификатор пользователя"
+// @Success      200      {object}  OverdueReviewsResponse  "Просроченные ревью"
+// @Failure      400      {object}  response.ErrorResponse  "MISSING_FIELD"
+// @Failure      404      {object}  response.ErrorResponse  "Пользователь не найден"
+// @Failure      500      {object}  response.ErrorResponse  "Внутренняя ошибка сервера"
+// @Router       /users/overdueReviews [get]
+func (handler *UsersHandler) OverdueReviews(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "user_id field is required")
+		return
+	}
+
+	reviews, err := handler.prService.OverdueReviewsByUser(r.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	overdueResponse := OverdueReviewsResponse{
+		UserID:  userID,
+		Reviews: make([]OverdueReviewResponse, 0, len(reviews)),
+	}
+	for _, review := range reviews {
+		overdueResponse.Reviews = append(overdueResponse.Reviews, OverdueReviewResponse{
+			PullRequestID:   review.PullRequestID,
+			PullRequestName: review.PullRequestName,
+			AuthorID:        review.AuthorID,
+			ReviewerID:      review.ReviewerID,
+			AssignedAt:      review.AssignedAt,
+			DueAt:           *review.DueAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, overdueResponse)
+}
+
+// AddUnavailability
+// @Summary      Запланировать период отсутствия пользователя
+// @Description  Сохраняет период [starts_at, ends_at) (RFC 3339) с причиной. Пока период покрывает текущий момент,
+// @Description  пользователь не выбирается ревьювером, при этом is_active не меняется и вручную возвращать его не нужно.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      AddUnavailabilityRequest   true  "Период отсутствия"
+// @Success      201      {object}  AddUnavailabilityResponse  "Сохранённый период"
+// @Failure      400      {object}  response.ErrorResponse     "INVALID_JSON / MISSING_FIELD / INVALID_PERIOD"
+// @Failure      404      {object}  response.ErrorResponse     "Пользователь не найден"
+// @Failure      500      {object}  response.ErrorResponse     "Внутренняя ошибка сервера"
+// @Router       /users/addUnavailability [post]
+func (handler *UsersHandler) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request AddUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.UserID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "user_id field is required")
+		return
+	}
+
+	period, err := handler.userService.AddUnavailability(r.Context(), request.UserID, request.StartsAt, request.EndsAt, request.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidUnavailability):
+			response.Error(w, http.StatusBadRequest, "INVALID_PERIOD", err.Error())
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, AddUnavailabilityResponse{Unavailability: toUnavailabilityResponse(period)})
+}
+
+// GetUnavailability
+// @Summary      Получить периоды отсутствия пользователя
+// @Description  Возвращает текущие и будущие периоды отсутствия пользователя по времени начала
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        user_id  query     string                     true  "Идентификатор пользователя"
+// @Success      200      {object}  GetUnavailabilityResponse  "Периоды отсутствия"
+// @Failure      400      {object}  response.ErrorResponse     "Некорректный запрос (нет user_id)"
+// @Failure      404      {object}  response.ErrorResponse     "Пользователь не найден"
+// @Failure      500      {object}  response.ErrorResponse     "Внутренняя ошибка сервера"
+// @Router       /users/getUnavailability [get]
+func (handler *UsersHandler) GetUnavailability(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "user_id field is required")
+		return
+	}
+
+	periods, err := handler.userService.ListUnavailability(r.Context(), userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	unavailabilityResponse := GetUnavailabilityResponse{
+		UserID:         userID,
+		Unavailability: make([]UnavailabilityResponse, 0, len(periods)),
+	}
+	for i := range periods {
+		unavailabilityResponse.Unavailability = append(unavailabilityResponse.Unavailability, toUnavailabilityResponse(&periods[i]))
+	}
+
+	response.JSON(w, http.StatusOK, unavailabilityResponse)
+}
+
+// DeleteUnavailability
+// @Summary      Отменить период отсутствия пользователя
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        request  body      DeleteUnavailabilityRequest  true  "Пользователь и период"
+// @Success      204      "Период удалён"
+// @Failure      400      {object}  response.ErrorResponse       "INVALID_JSON / MISSING_FIELD"
+// @Failure      404      {object}  response.ErrorResponse       "Период не найден"
+// @Failure      500      {object}  response.ErrorResponse       "Внутренняя ошибка сервера"
+// @Router       /users/deleteUnavailability [post]
+func (handler *UsersHandler) DeleteUnavailability(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request DeleteUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.UserID == "" || request.UnavailabilityID == 0 {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "user_id and unavailability_id fields are required")
+		return
+	}
+
+	err := handler.userService.DeleteUnavailability(r.Context(), request.UserID, request.UnavailabilityID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnavailabilityNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toUserResponse(user *domain.User) UserResponse {
+	return UserResponse{
+		UserID:         user.ID,
+		Username:       user.Username,
+		TeamName:       user.TeamName,
+		IsActive:       user.IsActive,
+		MaxOpenReviews: user.MaxOpenReviews,
+		Tags:           user.Tags,
+	}
+}
+
+func toUnavailabilityResponse(period *domain.Unavailability) UnavailabilityResponse {
+	return UnavailabilityResponse{
+		ID:       period.ID,
+		UserID:   period.UserID,
+		StartsAt: period.StartsAt,
+		EndsAt:   period.EndsAt,
+		Reason:   period.Reason,
+	}
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AddWebhookRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+	// EventTypes - ASSIGNED, UNASSIGNED, REASSIGNED, MERGED, CLOSED, REOPENED, REVIEWED; пусто - все события.
+	EventTypes []string `json:"event_types,omitempty"`
+}
+
+// WebhookResponse - подписка без секрета.
+type WebhookResponse struct {
+	WebhookID  int64     `json:"webhook_id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type AddWebhookResponse struct {
+	Webhook WebhookResponse `json:"webhook"`
+}
+
+type ListWebhooksResponse struct {
+	Webhooks []WebhookResponse `json:"webhooks"`
+}
+
+type DeleteWebhookRequest struct {
+	WebhookID int64 `json:"webhook_id"`
+}
+
+type DeadLetterResponse struct {
+	DeliveryID int64  `json:"delivery_id"`
+	WebhookID  int64  `json:"webhook_id"`
+	URL        string `json:"url"`
+	EventID    int64  `json:"event_id"`
+	EventType  string `json:"event_type"`
+	// Payload - тело, которое не удалось доставить.
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Attempts      int             `json:"attempts"`
+	LastError     *string         `json:"last_error,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+	LastAttemptAt *time.Time      `json:"last_attempt_at,omitempty"`
+}
+
+type DeadLettersResponse struct {
+	DeadLetters []DeadLetterResponse `json:"dead_letters"`
+}
@@ -0,0 +1,223 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/http/response"
+	"pr-reviewer-assigment-service/internal/service"
+	"strconv"
+)
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// Add godoc
+// @Summary Подписаться на события назначений
+// @Description
+//   - Сервис отправляет POST на url с JSON-описанием события (event_id, event_type, pull_request_id, reviewer_id,
+//     previous_reviewer_id, actor_id, reason, occurred_at).
+//   - Заголовок X-Webhook-Signature содержит sha256=<hex HMAC-SHA256 тела с secret>, X-Webhook-Event - тип события,
+//     X-Webhook-Delivery - ID доставки (одинаков при повторах).
+//   - event_types ограничивает события (ASSIGNED, UNASSIGNED, REASSIGNED, MERGED, CLOSED, REOPENED, REVIEWED), пусто - все.
+//   - Ответ не 2xx повторяется с экспоненциальной задержкой; после 8 неудач доставка попадает в /webhooks/deadLetters.
+//
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body AddWebhookRequest true "Подписка"
+// @Success 201 {object} AddWebhookResponse "Созданная подписка"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / INVALID_WEBHOOK"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /webhooks/add [post]
+func (handler *WebhookHandler) Add(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request AddWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	subscription := domain.WebhookSubscription{
+		URL:    request.URL,
+		Secret: request.Secret,
+	}
+	for _, eventType := range request.EventTypes {
+		subscription.EventTypes = append(subscription.EventTypes, domain.AssignmentEventType(eventType))
+	}
+
+	created, err := handler.webhookService.Subscribe(r.Context(), subscription)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidWebhook):
+			response.Error(w, http.StatusBadRequest, "INVALID_WEBHOOK",
+				"url must be http(s), secret is required and event_types must be known event types")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	response.JSON(w, http.StatusCreated, AddWebhookResponse{Webhook: toWebhookResponse(created)})
+}
+
+// List godoc
+// @Summary Получить подписки на вебхуки
+// @Tags Webhooks
+// @Produce json
+// @Success 200 {object} ListWebhooksResponse "Подписки в порядке создания, без секретов"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /webhooks/list [get]
+func (handler *WebhookHandler) List(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	subscriptions, err := handler.webhookService.ListSubscriptions(r.Context())
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		return
+	}
+
+	listResponse := ListWebhooksResponse{
+		Webhooks: make([]WebhookResponse, 0, len(subscriptions)),
+	}
+	for i := range subscriptions {
+		listResponse.Webhooks = append(listResponse.Webhooks, toWebhookResponse(&subscriptions[i]))
+	}
+
+	response.JSON(w, http.StatusOK, listResponse)
+}
+
+// Delete godoc
+// @Summary Удалить подписку на вебхуки
+// @Description Недоставленные события подписки удаляются вместе с ней.
+// @Tags Webhooks
+// @Accept json
+// @Produce json
+// @Param request body DeleteWebhookRequest true "Подписка"
+// @Success 204 "Подписка удалена"
+// @Failure 400 {object} response.ErrorResponse "INVALID_JSON / MISSING_FIELD"
+// @Failure 404 {object} response.ErrorResponse "Подписка не найдена"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /webhooks/delete [post]
+func (handler *WebhookHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	var request DeleteWebhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		response.Error(w, http.StatusBadRequest, "INVALID_JSON", "invalid request body")
+		return
+	}
+
+	if request.WebhookID == 0 {
+		response.Error(w, http.StatusBadRequest, "MISSING_FIELD", "webhook_id field is required")
+		return
+	}
+
+	if err := handler.webhookService.Unsubscribe(r.Context(), request.WebhookID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrWebhookNotFound):
+			response.Error(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		default:
+			response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeadLetters godoc
+// @Summary Получить недоставленные вебхуки
+// @Description Доставки, исчерпавшие попытки, от новых к старым.
+// @Tags Webhooks
+// @Produce json
+// @Param webhook_id query int false "Только доставки этой подписки"
+// @Param limit query int false "Лимит выборки (по умолчанию 50, максимум 100)"
+// @Success 200 {object} DeadLettersResponse "Недоставленные вебхуки"
+// @Failure 400 {object} response.ErrorResponse "INVALID_WEBHOOK_ID"
+// @Failure 500 {object} response.ErrorResponse "INTERNAL_ERROR"
+// @Router /webhooks/deadLetters [get]
+func (handler *WebhookHandler) DeadLetters(w http.ResponseWriter, r *http.Request) {
+	defer func() {
+		_ = r.Body.Close()
+	}()
+
+	w.Header().Set("Content-Type", "application/json")
+	query := r.URL.Query()
+
+	var subscriptionID *int64
+	if value := query.Get("webhook_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			response.Error(w, http.StatusBadRequest, "INVALID_WEBHOOK_ID", "webhook_id must be an integer")
+			return
+		}
+		subscriptionID = &id
+	}
+
+	limit := 50
+	if v, err := strconv.Atoi(query.Get("limit")); err == nil && v > 0 {
+		limit = min(v, 100)
+	}
+
+	deliveries, err := handler.webhookService.DeadLetters(r.Context(), subscriptionID, limit)
+	if err != nil {
+		response.Error(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal server error")
+		return
+	}
+
+	deadLettersResponse := DeadLettersResponse{
+		DeadLetters: make([]DeadLetterResponse, 0, len(deliveries)),
+	}
+	for _, delivery := range deliveries {
+		deadLettersResponse.DeadLetters = append(deadLettersResponse.DeadLetters, DeadLetterResponse{
+			DeliveryID:    delivery.ID,
+			WebhookID:     delivery.SubscriptionID,
+			URL:           delivery.URL,
+			EventID:       delivery.EventID,
+			EventType:     string(delivery.EventType),
+			Payload:       delivery.Payload,
+			Attempts:      delivery.Attempts,
+			LastError:     delivery.LastError,
+			CreatedAt:     delivery.CreatedAt,
+			LastAttemptAt: delivery.LastAttemptAt,
+		})
+	}
+
+	response.JSON(w, http.StatusOK, deadLettersResponse)
+}
+
+func toWebhookResponse(subscription *domain.WebhookSubscription) WebhookResponse {
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return WebhookResponse{
+		WebhookID:  subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: eventTypes,
+		CreatedAt:  subscription.CreatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLeader выбирает лидера среди экземпляров сервиса через сессионную advisory-блокировку Postgres.
+// Блокировка держится на выделенном соединении пула, пока оно живо или не вызван Release.
+type AdvisoryLeader struct {
+	pool *pgxpool.Pool
+	key  int64
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+// NewAdvisoryLeader создаёт выбор лидера по ключу блокировки key.
+func NewAdvisoryLeader(pool *pgxpool.Pool, key int64) *AdvisoryLeader {
+	return &AdvisoryLeader{pool: pool, key: key}
+}
+
+// TryAcquire сообщает, является ли экземпляр лидером, захватывая блокировку при необходимости.
+// Если соединение с блокировкой оборвалось, лидерство считается потерянным и захватывается заново.
+func (leader *AdvisoryLeader) TryAcquire(ctx context.Context) (bool, error) {
+	leader.mu.Lock()
+	defer leader.mu.Unlock()
+
+	if leader.conn != nil {
+		if err := leader.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		// вместе с сессией Postgres снял и блокировку
+		_ = leader.conn.Conn().Close(ctx)
+		leader.conn.Release()
+		leader.conn = nil
+	}
+
+	conn, err := leader.pool.Acquire(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var acquired bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, leader.key).Scan(&acquired); err != nil {
+		conn.Release()
+		return false, err
+	}
+	if !acquired {
+		conn.Release()
+		return false, nil
+	}
+
+	leader.conn = conn
+	return true, nil
+}
+
+// Release снимает блокировку и возвращает соединение в пул; без лидерства ничего не делает.
+func (leader *AdvisoryLeader) Release(ctx context.Context) error {
+	leader.mu.Lock()
+	defer leader.mu.Unlock()
+
+	if leader.conn == nil {
+		return nil
+	}
+
+	_, err := leader.conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, leader.key)
+	if err != nil {
+		// не отдаём в пул соединение, которое может держать блокировку
+		_ = leader.conn.Conn().Close(ctx)
+	}
+	leader.conn.Release()
+	leader.conn = nil
+
+	return err
+}
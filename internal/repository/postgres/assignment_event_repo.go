@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AssignmentEventRepository - append-only история назначений ревьюверов (prs.assignment_events)
+type AssignmentEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAssignmentEventRepository - создает репозиторий истории назначений
+func NewAssignmentEventRepository(pool *pgxpool.Pool) *AssignmentEventRepository {
+	return &AssignmentEventRepository{pool: pool}
+}
+
+// Append добавляет события в историю и заполняет их ID и CreatedAt;
+// внутри транзакции пишет вместе с изменением ревьюверов
+func (repo *AssignmentEventRepository) Append(ctx context.Context, events []domain.AssignmentEvent) error {
+	const qInsertEvent = `
+		INSERT INTO prs.assignment_events (pr_id, event_type, reviewer_id, previous_reviewer_id, actor_id, reason)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at
+	`
+
+	q := conn(ctx, repo.pool)
+	for i, event := range events {
+		err := q.QueryRow(ctx, qInsertEvent,
+			event.PullRequestID,
+			event.Type,
+			event.ReviewerID,
+			event.PreviousReviewerID,
+			event.ActorID,
+			event.Reason,
+		).Scan(&events[i].ID, &events[i].CreatedAt)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ListByPR возвращает историю назначений PR в порядке записи
+func (repo *AssignmentEventRepository) ListByPR(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	const qListEvents = `
+		SELECT
+			e.id,
+			e.event_type,
+			e.reviewer_id,
+			e.previous_reviewer_id,
+			e.actor_id,
+			e.reason,
+			e.created_at
+		FROM prs.pull_requests pr
+		LEFT JOIN prs.assignment_events e ON e.pr_id = pr.id
+		WHERE pr.id = $1
+		ORDER BY e.id
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListEvents, prID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	found := false
+	events := make([]domain.AssignmentEvent, 0)
+	for rows.Next() {
+		found = true
+
+		var (
+			id        *int64
+			eventType *domain.AssignmentEventType
+			reason    *string
+			createdAt *time.Time
+			event     domain.AssignmentEvent
+		)
+		err = rows.Scan(&id, &eventType, &event.ReviewerID, &event.PreviousReviewerID, &event.ActorID, &reason, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+
+		// PR без событий приходит одной строкой с NULL из LEFT JOIN
+		if id == nil {
+			continue
+		}
+
+		event.ID = *id
+		event.PullRequestID = prID
+		event.Type = *eventType
+		event.Reason = *reason
+		event.CreatedAt = *createdAt
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, domain.ErrPRNotFound
+	}
+
+	return events, nil
+}
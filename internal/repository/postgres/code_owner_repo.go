@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CodeOwnerRepository - правила владения путями команд (users.code_owners)
+type CodeOwnerRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewCodeOwnerRepository - создает репозиторий правил владения путями
+func NewCodeOwnerRepository(pool *pgxpool.Pool) *CodeOwnerRepository {
+	return &CodeOwnerRepository{pool: pool}
+}
+
+// ListByTeam возвращает правила команды в порядке их задания
+func (repo *CodeOwnerRepository) ListByTeam(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	const qListCodeOwners = `
+		SELECT co.pattern, co.owner_user_id, ot.name
+		FROM users.code_owners co
+		JOIN users.teams t ON t.id = co.team_id
+		LEFT JOIN users.teams ot ON ot.id = co.owner_team_id
+		WHERE t.name = $1
+		ORDER BY co.position
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListCodeOwners, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.CodeOwnerRule, 0)
+	for rows.Next() {
+		var rule domain.CodeOwnerRule
+		if err := rows.Scan(&rule.Pattern, &rule.OwnerUserID, &rule.OwnerTeam); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Replace заменяет все правила команды teamName на rules, порядок в rules сохраняется
+func (repo *CodeOwnerRepository) Replace(ctx context.Context, teamName string, rules []domain.CodeOwnerRule) (err error) {
+	tx, err := begin(ctx, repo.pool)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		} else if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	var teamID int64
+	err = tx.QueryRow(ctx, `SELECT id FROM users.teams WHERE name = $1`, teamName).Scan(&teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTeamNotFound
+		}
+		return err
+	}
+
+	if _, err = tx.Exec(ctx, `DELETE FROM users.code_owners WHERE team_id = $1`, teamID); err != nil {
+		return err
+	}
+
+	const qInsertUserOwner = `
+		INSERT INTO users.code_owners (team_id, position, pattern, owner_user_id)
+		VALUES ($1, $2, $3, $4)
+	`
+	const qInsertTeamOwner = `
+		INSERT INTO users.code_owners (team_id, position, pattern, owner_team_id)
+		SELECT $1, $2, $3, t.id
+		FROM users.teams t
+		WHERE t.name = $4
+	`
+
+	for position, rule := range rules {
+		if rule.OwnerUserID != nil {
+			_, err = tx.Exec(ctx, qInsertUserOwner, teamID, position, rule.Pattern, *rule.OwnerUserID)
+			if err != nil {
+				var pgErr *pgconn.PgError
+				if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+					return domain.ErrUserNotFound
+				}
+				return err
+			}
+			continue
+		}
+
+		cmdTag, err := tx.Exec(ctx, qInsertTeamOwner, teamID, position, rule.Pattern, *rule.OwnerTeam)
+		if err != nil {
+			return err
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return domain.ErrTeamNotFound
+		}
+	}
+
+	return nil
+}
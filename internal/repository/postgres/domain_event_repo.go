@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// domainEventsChannel - канал LISTEN/NOTIFY, в который сообщается о записи событий.
+const domainEventsChannel = "domain_events"
+
+// DomainEventRepository - append-only outbox доменных событий (events.domain_events)
+type DomainEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDomainEventRepository - создает репозиторий доменных событий
+func NewDomainEventRepository(pool *pgxpool.Pool) *DomainEventRepository {
+	return &DomainEventRepository{pool: pool}
+}
+
+// Append записывает события и уведомляет слушателей domainEventsChannel; внутри транзакции
+// события и уведомление становятся видны только после коммита
+func (repo *DomainEventRepository) Append(ctx context.Context, events []domain.DomainEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	const qInsertEvent = `
+		INSERT INTO events.domain_events (event_type, payload)
+		VALUES ($1, $2)
+	`
+
+	q := conn(ctx, repo.pool)
+	for _, event := range events {
+		if _, err := q.Exec(ctx, qInsertEvent, event.Type, event.Payload); err != nil {
+			return err
+		}
+	}
+
+	_, err := q.Exec(ctx, `SELECT pg_notify($1, '')`, domainEventsChannel)
+	return err
+}
+
+// ListAfter возвращает до limit событий после курсора after. События транзакции видны,
+// только когда завершены все более ранние транзакции, поэтому поток читается без пропусков,
+// но стоит, пока открыта любая более ранняя транзакция в кластере (см. Lag)
+func (repo *DomainEventRepository) ListAfter(
+	ctx context.Context,
+	after domain.EventCursor,
+	limit int,
+) ([]domain.DomainEvent, error) {
+	const qListEvents = `
+		SELECT id, tx_id, event_type, payload, created_at
+		FROM events.domain_events
+		WHERE (tx_id, id) > ($1, $2)
+		  AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+		ORDER BY tx_id, id
+		LIMIT $3
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListEvents, after.TxID, after.ID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]domain.DomainEvent, 0)
+	for rows.Next() {
+		var event domain.DomainEvent
+		if err := rows.Scan(&event.ID, &event.Cursor.TxID, &event.Type, &event.Payload, &event.CreatedAt); err != nil {
+			return nil, err
+		}
+		event.Cursor.ID = event.ID
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// Lag возвращает, сколько закоммиченных событий после курсора after ещё не выдаёт ListAfter
+// из-за открытой более ранней транзакции, и когда записано самое раннее из них
+func (repo *DomainEventRepository) Lag(ctx context.Context, after domain.EventCursor) (domain.EventLag, error) {
+	const qEventLag = `
+		SELECT COUNT(*), MIN(created_at)
+		FROM events.domain_events
+		WHERE (tx_id, id) > ($1, $2)
+		  AND tx_id >= pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+	`
+
+	var lag domain.EventLag
+	err := conn(ctx, repo.pool).QueryRow(ctx, qEventLag, after.TxID, after.ID).Scan(&lag.HeldBack, &lag.Since)
+	if err != nil {
+		return domain.EventLag{}, err
+	}
+	return lag, nil
+}
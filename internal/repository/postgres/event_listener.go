@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// eventListenerRetryDelay - пауза перед переподключением после обрыва соединения.
+const eventListenerRetryDelay = 5 * time.Second
+
+// EventListener слушает domainEventsChannel на выделенном соединении и будит ожидающих новых событий.
+type EventListener struct {
+	pool *pgxpool.Pool
+
+	mu      sync.Mutex
+	changed chan struct{}
+}
+
+// NewEventListener создаёт слушателя; уведомления приходят после запуска Run.
+func NewEventListener(pool *pgxpool.Pool) *EventListener {
+	return &EventListener{
+		pool:    pool,
+		changed: make(chan struct{}),
+	}
+}
+
+// Changed возвращает канал, который закроется при следующем уведомлении о записи событий.
+func (listener *EventListener) Changed() <-chan struct{} {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	return listener.changed
+}
+
+// Run слушает уведомления до отмены ctx, переподключаясь после обрыва соединения.
+func (listener *EventListener) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := listener.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("events: listener failed: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventListenerRetryDelay):
+		}
+	}
+}
+
+func (listener *EventListener) listen(ctx context.Context) error {
+	pooled, err := listener.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// соединение с LISTEN не возвращается в пул
+	conn := pooled.Hijack()
+	defer func() {
+		_ = conn.Close(context.WithoutCancel(ctx))
+	}()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+domainEventsChannel); err != nil {
+		return err
+	}
+	// пока соединения не было, уведомления могли потеряться
+	listener.broadcast()
+
+	for {
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		listener.broadcast()
+	}
+}
+
+// broadcast будит всех, кто ждёт на текущем канале Changed.
+func (listener *EventListener) broadcast() {
+	listener.mu.Lock()
+	defer listener.mu.Unlock()
+
+	close(listener.changed)
+	listener.changed = make(chan struct{})
+}
@@ -3,10 +3,14 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"pr-reviewer-assigment-service/internal/domain"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -20,42 +24,113 @@ func NewPullRequestRepository(pool *pgxpool.Pool) *PullRequestRepository {
 	}
 }
 
-func (repo *PullRequestRepository) GetReviewPRs(ctx context.Context, userID string) ([]domain.PullRequest, error) {
-	const qGetReviews = `
-		SELECT
-			prr.pr_id,
-			pr.title,
-			pr.author_id,
-			pr.status
-		FROM prs.pr_reviewers as prr
-		JOIN prs.pull_requests pr ON pr.id = prr.pr_id
-		WHERE prr.user_id = $1
+// assignedReviewQuery выбирает назначения ревьюверов вместе с SLA и рабочим днём команды ревьювера;
+// условие и порядок дописываются вызывающим.
+const assignedReviewQuery = `
+	SELECT
+		pr.id,
+		pr.title,
+		pr.author_id,
+		pr.status,
+		pr.version,
+		prr.user_id,
+		prr.assigned_at,
+		prr.state,
+		t.review_sla_hours,
+		t.auto_reassign_hours,
+		t.business_day_start,
+		t.business_day_end,
+		t.business_time_zone
+	FROM prs.pr_reviewers prr
+	JOIN prs.pull_requests pr ON pr.id = prr.pr_id
+	LEFT JOIN users.team_members tm ON tm.user_id = prr.user_id
+	LEFT JOIN users.teams t ON t.id = tm.team_id
+`
+
+// GetReviewPRs возвращает назначения пользователя во всех PR, кроме закрытых
+func (repo *PullRequestRepository) GetReviewPRs(ctx context.Context, userID string) ([]domain.AssignedReview, error) {
+	const qGetReviews = assignedReviewQuery + `
+		WHERE prr.user_id = $1 AND pr.status <> 'CLOSED'
+		ORDER BY prr.assigned_at, pr.id
+	`
+
+	return repo.queryAssignedReviews(ctx, qGetReviews, userID)
+}
+
+// ListPendingReviewsByTeam возвращает назначения участников команды в открытых PR, по которым ещё нет вердикта
+func (repo *PullRequestRepository) ListPendingReviewsByTeam(ctx context.Context, teamName string) ([]domain.AssignedReview, error) {
+	const qPendingReviews = assignedReviewQuery + `
+		WHERE t.name = $1 AND pr.status = 'OPEN' AND prr.state = 'PENDING'
+		ORDER BY prr.assigned_at, pr.id, prr.user_id
+	`
+
+	return repo.queryAssignedReviews(ctx, qPendingReviews, teamName)
+}
+
+// ListAutoReassignableReviews возвращает назначения без вердикта в открытых PR у ревьюверов, чья команда
+// задала порог автоматического переназначения; упорядочены по PR
+func (repo *PullRequestRepository) ListAutoReassignableReviews(ctx context.Context) ([]domain.AssignedReview, error) {
+	const qAutoReassignable = assignedReviewQuery + `
+		WHERE t.auto_reassign_hours IS NOT NULL AND pr.status = 'OPEN' AND prr.state = 'PENDING'
+		ORDER BY pr.id, prr.user_id
 	`
 
-	var prs []domain.PullRequest
-	rows, err := repo.pool.Query(ctx, qGetReviews, userID)
+	return repo.queryAssignedReviews(ctx, qAutoReassignable)
+}
+
+func (repo *PullRequestRepository) queryAssignedReviews(ctx context.Context, query string, args ...any) ([]domain.AssignedReview, error) {
+	rows, err := conn(ctx, repo.pool).Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
+
+	reviews := make([]domain.AssignedReview, 0)
 	for rows.Next() {
-		var pr domain.PullRequest
-		err = rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.Status)
+		var (
+			review   domain.AssignedReview
+			settings domain.TeamSettings
+			timeZone *string
+		)
+		err = rows.Scan(
+			&review.PullRequestID,
+			&review.PullRequestName,
+			&review.AuthorID,
+			&review.Status,
+			&review.Version,
+			&review.ReviewerID,
+			&review.AssignedAt,
+			&review.State,
+			&review.SLAHours,
+			&review.AutoReassignHours,
+			&settings.BusinessDayStart,
+			&settings.BusinessDayEnd,
+			&timeZone,
+		)
 		if err != nil {
 			return nil, err
 		}
-		prs = append(prs, pr)
+		if timeZone != nil {
+			settings.BusinessTimeZone = *timeZone
+		}
+
+		// ревьювер без команды считается по рабочему дню по умолчанию
+		review.BusinessHours, err = settings.BusinessHours()
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
 	}
 
 	if rows.Err() != nil {
 		return nil, rows.Err()
 	}
 
-	return prs, nil
+	return reviews, nil
 }
 
-func (repo *PullRequestRepository) Create(ctx context.Context, prID, prName, authorID string) error {
-	tx, err := repo.pool.Begin(ctx)
+func (repo *PullRequestRepository) Create(ctx context.Context, prID, prName, authorID string, requiredReviewers int, tags []string) (err error) {
+	tx, err := begin(ctx, repo.pool)
 	if err != nil {
 		return err
 	}
@@ -63,35 +138,46 @@ func (repo *PullRequestRepository) Create(ctx context.Context, prID, prName, aut
 	defer func() {
 		if p := recover(); p != nil {
 			_ = tx.Rollback(ctx)
+			panic(p)
 		} else if err != nil {
 			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
 		}
-		err = tx.Commit(ctx)
 	}()
 
-	const qCreatePR = `INSERT INTO prs.pull_requests(id, title, author_id) VALUES ($1, $2, $3)`
+	const qCreatePR = `
+		INSERT INTO prs.pull_requests(id, title, author_id, required_reviewers, tags)
+		VALUES ($1, $2, $3, $4, COALESCE($5, '{}'))
+	`
 
-	_, err = tx.Exec(ctx, qCreatePR, prID, prName, authorID)
+	_, err = tx.Exec(ctx, qCreatePR, prID, prName, authorID, requiredReviewers, tags)
 
 	if err != nil {
-		return domain.ErrPRIsExists
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return domain.ErrPRIsExists
+		}
+		return err
 	}
 
 	return nil
 }
 
-func (repo *PullRequestRepository) AssignReviewers(ctx context.Context, prID string, reviewers []string) error {
-	tx, err := repo.pool.Begin(ctx)
+func (repo *PullRequestRepository) AssignReviewers(ctx context.Context, prID string, reviewers []string) (err error) {
+	tx, err := begin(ctx, repo.pool)
 	if err != nil {
 		return err
 	}
 	defer func() {
 		if p := recover(); p != nil {
 			_ = tx.Rollback(ctx)
+			panic(p)
 		} else if err != nil {
 			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
 		}
-		err = tx.Commit(ctx)
 	}()
 
 	const qInsertReviewer = `
@@ -107,8 +193,8 @@ func (repo *PullRequestRepository) AssignReviewers(ctx context.Context, prID str
 	return nil
 }
 
-func (repo *PullRequestRepository) Merge(ctx context.Context, prID string) (*domain.PullRequestAssignment, error) {
-	tx, err := repo.pool.Begin(ctx)
+func (repo *PullRequestRepository) Merge(ctx context.Context, prID string) (_ *domain.PullRequestAssignment, err error) {
+	tx, err := begin(ctx, repo.pool)
 	if err != nil {
 		return nil, err
 	}
@@ -125,9 +211,10 @@ func (repo *PullRequestRepository) Merge(ctx context.Context, prID string) (*dom
 	}()
 
 	const qSelectPR = `
-		SELECT id, title, author_id, status, merged_at
+		SELECT id, title, author_id, status, merged_at, version
 		FROM prs.pull_requests
 		WHERE id = $1
+		FOR NO KEY UPDATE
 	`
 
 	var (
@@ -136,9 +223,10 @@ func (repo *PullRequestRepository) Merge(ctx context.Context, prID string) (*dom
 		authorID string
 		status   domain.PRStatus
 		mergedAt *time.Time
+		version  int64
 	)
 
-	err = tx.QueryRow(ctx, qSelectPR, prID).Scan(&id, &name, &authorID, &status, &mergedAt)
+	err = tx.QueryRow(ctx, qSelectPR, prID).Scan(&id, &name, &authorID, &status, &mergedAt, &version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrPRNotFound
@@ -146,15 +234,21 @@ func (repo *PullRequestRepository) Merge(ctx context.Context, prID string) (*dom
 		return nil, err
 	}
 
+	if status == domain.PRClosedStatus {
+		return nil, domain.ErrPRClosed
+	}
+
 	if status != domain.PRMergeStatus {
 		const qUpdate = `
 		UPDATE prs.pull_requests
 		SET status = $2,
-		    merged_at = NOW()
+		    merged_at = NOW(),
+		    version = version + 1
 		WHERE id = $1
+		RETURNING merged_at, version
 	`
 
-		if _, err = tx.Exec(ctx, qUpdate, prID, domain.PRMergeStatus); err != nil {
+		if err = tx.QueryRow(ctx, qUpdate, prID, domain.PRMergeStatus).Scan(&mergedAt, &version); err != nil {
 			return nil, err
 		}
 	}
@@ -185,116 +279,443 @@ func (repo *PullRequestRepository) Merge(ctx context.Context, prID string) (*dom
 		return nil, rows.Err()
 	}
 
-	if mergedAt == nil {
-		err = tx.QueryRow(ctx, `SELECT merged_at FROM prs.pull_requests WHERE id = $1`, prID).Scan(&mergedAt)
-		if err != nil {
-			return nil, err
-		}
-	}
-
 	return &domain.PullRequestAssignment{
 		PullRequest: domain.PullRequest{
 			PullRequestID:   prID,
 			PullRequestName: name,
 			AuthorID:        authorID,
 			Status:          domain.PRMergeStatus,
+			Version:         version,
 		},
 		AssignedReviewers: reviewers,
 		MergedAt:          mergedAt,
 	}, nil
 }
 
-func (repo *PullRequestRepository) IsExists(ctx context.Context, prID string) (bool, error) {
-	const qExistsPR = `SELECT EXISTS (
-SELECT 1
-FROM prs.pull_requests
-WHERE id = $1
-);`
-	var exists bool
-	err := repo.pool.QueryRow(ctx, qExistsPR, prID).Scan(&exists)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return exists, nil
-		}
-		return false, err
-	}
-	return exists, nil
-}
-
-func (repo *PullRequestRepository) GetPRReviewers(ctx context.Context, prID string) ([]string, error) {
-	const qPRReviews = `
-		SELECT user_id
-		FROM prs.pr_reviewers
-		WHERE pr_id = $1
+// ListOpenReviews возвращает назначения пользователей userIDs в открытых PR, упорядоченные по PR.
+func (repo *PullRequestRepository) ListOpenReviews(ctx context.Context, userIDs []string) ([]domain.ReviewAssignment, error) {
+	const qOpenReviews = `
+		SELECT prr.pr_id, prr.user_id
+		FROM prs.pr_reviewers prr
+		JOIN prs.pull_requests pr ON pr.id = prr.pr_id
+		WHERE prr.user_id = ANY($1) AND pr.status = 'OPEN'
+		ORDER BY prr.pr_id, prr.user_id
 	`
 
-	rows, err := repo.pool.Query(ctx, qPRReviews, prID)
+	rows, err := conn(ctx, repo.pool).Query(ctx, qOpenReviews, userIDs)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var reviewers []string
+
+	assignments := make([]domain.ReviewAssignment, 0)
 	for rows.Next() {
-		var rid string
-		if err := rows.Scan(&rid); err != nil {
+		var assignment domain.ReviewAssignment
+		if err := rows.Scan(&assignment.PullRequestID, &assignment.UserID); err != nil {
 			return nil, err
 		}
-		reviewers = append(reviewers, rid)
+		assignments = append(assignments, assignment)
 	}
-	if rows.Err() != nil {
-		return nil, rows.Err()
+
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
-	return reviewers, nil
+
+	return assignments, nil
 }
 
-func (repo *PullRequestRepository) GetPRAuthors(ctx context.Context, prID string) (string, error) {
-	const qGetPRAuthor = `
-		SELECT author_id
+// LockPR блокирует строку PR до конца транзакции из ctx и возвращает её текущую версию.
+func (repo *PullRequestRepository) LockPR(ctx context.Context, prID string) (int64, error) {
+	const qLockPR = `
+		SELECT version
 		FROM prs.pull_requests
 		WHERE id = $1
+		FOR NO KEY UPDATE
 	`
 
-	var authorID string
-	err := repo.pool.QueryRow(ctx, qGetPRAuthor, prID).Scan(&authorID)
+	var version int64
+	err := conn(ctx, repo.pool).QueryRow(ctx, qLockPR, prID).Scan(&version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return authorID, domain.ErrPRNotFound
+			return 0, domain.ErrPRNotFound
 		}
-		return authorID, err
+		return 0, err
 	}
-	return authorID, nil
+	return version, nil
 }
 
-func (repo *PullRequestRepository) GetPRNameByID(ctx context.Context, prID string) (string, error) {
-	const qPRName = `SELECT title FROM prs.pull_requests WHERE id = $1`
-	var name string
-	err := repo.pool.QueryRow(ctx, qPRName, prID).Scan(&name)
+// Close переводит открытый PR в CLOSED и увеличивает версию; для PR в другом статусе ничего не меняет.
+func (repo *PullRequestRepository) Close(ctx context.Context, prID string) error {
+	const qClosePR = `
+		UPDATE prs.pull_requests
+		SET status = 'CLOSED',
+		    closed_at = NOW(),
+		    version = version + 1
+		WHERE id = $1 AND status = 'OPEN'
+	`
+
+	_, err := conn(ctx, repo.pool).Exec(ctx, qClosePR, prID)
+	return err
+}
+
+// Reopen возвращает закрытый PR в OPEN и увеличивает версию; для PR в другом статусе ничего не меняет.
+func (repo *PullRequestRepository) Reopen(ctx context.Context, prID string) error {
+	const qReopenPR = `
+		UPDATE prs.pull_requests
+		SET status = 'OPEN',
+		    closed_at = NULL,
+		    version = version + 1
+		WHERE id = $1 AND status = 'CLOSED'
+	`
+
+	_, err := conn(ctx, repo.pool).Exec(ctx, qReopenPR, prID)
+	return err
+}
+
+// BumpVersion увеличивает версию PR после изменения его ревьюверов и возвращает новую.
+func (repo *PullRequestRepository) BumpVersion(ctx context.Context, prID string) (int64, error) {
+	const qBumpVersion = `
+		UPDATE prs.pull_requests
+		SET version = version + 1
+		WHERE id = $1
+		RETURNING version
+	`
+
+	var version int64
+	err := conn(ctx, repo.pool).QueryRow(ctx, qBumpVersion, prID).Scan(&version)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return name, domain.ErrPRNotFound
+			return 0, domain.ErrPRNotFound
 		}
-		return name, err
+		return 0, err
 	}
-	return name, nil
+	return version, nil
 }
 
-func (repo *PullRequestRepository) DeleteAssignedUser(ctx context.Context, prID string) error {
-	tx, err := repo.pool.Begin(ctx)
+// GetByID возвращает PR с ревьюверами и временными метками одним запросом.
+func (repo *PullRequestRepository) GetByID(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	const qGetPR = `
+		SELECT
+			pr.id,
+			pr.title,
+			pr.author_id,
+			pr.status,
+			pr.version,
+			pr.required_reviewers,
+			pr.tags,
+			pr.created_at,
+			pr.merged_at,
+			pr.closed_at,
+			prr.user_id,
+			prr.assigned_at,
+			prr.state,
+			prr.reviewed_at
+		FROM prs.pull_requests pr
+		LEFT JOIN prs.pr_reviewers prr ON prr.pr_id = pr.id
+		WHERE pr.id = $1
+		ORDER BY prr.assigned_at, prr.user_id
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qGetPR, prID)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	defer func() {
-		if p := recover(); p != nil {
-			_ = tx.Rollback(ctx)
-		} else if err != nil {
-			_ = tx.Rollback(ctx)
+	defer rows.Close()
+
+	var pr *domain.PullRequestDetails
+	for rows.Next() {
+		var (
+			details    domain.PullRequestDetails
+			reviewerID *string
+			assignedAt *time.Time
+			state      *domain.ReviewState
+			reviewedAt *time.Time
+		)
+		err = rows.Scan(
+			&details.PullRequestID,
+			&details.PullRequestName,
+			&details.AuthorID,
+			&details.Status,
+			&details.Version,
+			&details.RequiredReviewers,
+			&details.Tags,
+			&details.CreatedAt,
+			&details.MergedAt,
+			&details.ClosedAt,
+			&reviewerID,
+			&assignedAt,
+			&state,
+			&reviewedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if pr == nil {
+			details.Reviewers = make([]domain.Reviewer, 0)
+			pr = &details
 		}
-		err = tx.Commit(ctx)
-	}()
 
-	_, err = tx.Exec(ctx, `DELETE FROM prs.pr_reviewers WHERE pr_id = $1`, prID)
+		// PR без ревьюверов приходит одной строкой с NULL из LEFT JOIN
+		if reviewerID != nil {
+			pr.Reviewers = append(pr.Reviewers, domain.Reviewer{
+				UserID:     *reviewerID,
+				AssignedAt: *assignedAt,
+				State:      *state,
+				ReviewedAt: reviewedAt,
+			})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if pr == nil {
+		return nil, domain.ErrPRNotFound
+	}
+
+	return pr, nil
+}
+
+// AddReviewer назначает userID ревьювером PR; повторное назначение возвращает domain.ErrAlreadyAssigned
+func (repo *PullRequestRepository) AddReviewer(ctx context.Context, prID, userID string) error {
+	const qInsertReviewer = `
+		INSERT INTO prs.pr_reviewers (pr_id, user_id)
+		VALUES ($1, $2)
+		ON CONFLICT (pr_id, user_id) DO NOTHING
+	`
+
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qInsertReviewer, prID, userID)
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return domain.ErrUserNotFound
+		}
 		return err
 	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrAlreadyAssigned
+	}
+
 	return nil
 }
+
+// RemoveReviewer снимает userID с PR; если он не назначен, возвращается domain.ErrIsNotAssigned
+func (repo *PullRequestRepository) RemoveReviewer(ctx context.Context, prID, userID string) error {
+	const qDeleteReviewer = `
+		DELETE FROM prs.pr_reviewers
+		WHERE pr_id = $1 AND user_id = $2
+	`
+
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qDeleteReviewer, prID, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrIsNotAssigned
+	}
+
+	return nil
+}
+
+// SetReviewState сохраняет вердикт ревьювера userID; если он не назначен, возвращается domain.ErrIsNotAssigned
+func (repo *PullRequestRepository) SetReviewState(ctx context.Context, prID, userID string, state domain.ReviewState) error {
+	const qUpdateState = `
+		UPDATE prs.pr_reviewers
+		SET state = $3, reviewed_at = NOW()
+		WHERE pr_id = $1 AND user_id = $2
+	`
+
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qUpdateState, prID, userID, state)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrIsNotAssigned
+	}
+
+	return nil
+}
+
+// List возвращает страницу PR по фильтрам с keyset-пагинацией.
+// Total считается по фильтрам без учёта курсора.
+func (repo *PullRequestRepository) List(ctx context.Context, query domain.PullRequestListQuery) (*domain.PullRequestPage, error) {
+	var (
+		conditions []string
+		args       []any
+	)
+	arg := func(value any) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if query.Status != "" {
+		conditions = append(conditions, "pr.status = "+arg(query.Status))
+	}
+	if query.AuthorID != "" {
+		conditions = append(conditions, "pr.author_id = "+arg(query.AuthorID))
+	}
+	if query.ReviewerID != "" {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM prs.pr_reviewers prr
+			WHERE prr.pr_id = pr.id AND prr.user_id = `+arg(query.ReviewerID)+`
+		)`)
+	}
+	if query.TeamName != "" {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1
+			FROM users.team_members tm
+			JOIN users.teams t ON t.id = tm.team_id
+			WHERE tm.user_id = pr.author_id AND t.name = `+arg(query.TeamName)+`
+		)`)
+	}
+	if query.CreatedFrom != nil {
+		conditions = append(conditions, "pr.created_at >= "+arg(*query.CreatedFrom))
+	}
+	if query.CreatedTo != nil {
+		conditions = append(conditions, "pr.created_at < "+arg(*query.CreatedTo))
+	}
+	if query.MergedFrom != nil {
+		conditions = append(conditions, "pr.merged_at >= "+arg(*query.MergedFrom))
+	}
+	if query.MergedTo != nil {
+		conditions = append(conditions, "pr.merged_at < "+arg(*query.MergedTo))
+	}
+
+	where := "TRUE"
+	if len(conditions) > 0 {
+		where = strings.Join(conditions, " AND ")
+	}
+	filterArgs := len(args)
+
+	direction, compare := "ASC", ">"
+	if query.Desc {
+		direction, compare = "DESC", "<"
+	}
+
+	orderBy := "f.id " + direction
+	if query.Sort == domain.PRSortCreatedAt {
+		orderBy = "f.created_at " + direction + ", f.id " + direction
+	}
+
+	after := "TRUE"
+	if cursor := query.Cursor; cursor != nil {
+		if query.Sort == domain.PRSortCreatedAt {
+			after = "(f.created_at, f.id) " + compare + " (" + arg(cursor.CreatedAt) + ", " + arg(cursor.ID) + ")"
+		} else {
+			after = "f.id " + compare + " " + arg(cursor.ID)
+		}
+	}
+
+	qListPRs := `
+		WITH filtered AS (
+			SELECT pr.id, pr.title, pr.author_id, pr.status, pr.version, pr.required_reviewers, pr.tags, pr.created_at, pr.merged_at, pr.closed_at
+			FROM prs.pull_requests pr
+			WHERE ` + where + `
+		)
+		SELECT
+			f.id,
+			f.title,
+			f.author_id,
+			f.status,
+			f.version,
+			f.required_reviewers,
+			f.tags,
+			f.created_at,
+			f.merged_at,
+			f.closed_at,
+			ARRAY(SELECT prr.user_id FROM prs.pr_reviewers prr WHERE prr.pr_id = f.id ORDER BY prr.assigned_at, prr.user_id),
+			ARRAY(SELECT prr.assigned_at FROM prs.pr_reviewers prr WHERE prr.pr_id = f.id ORDER BY prr.assigned_at, prr.user_id),
+			ARRAY(SELECT prr.state FROM prs.pr_reviewers prr WHERE prr.pr_id = f.id ORDER BY prr.assigned_at, prr.user_id),
+			ARRAY(SELECT prr.reviewed_at FROM prs.pr_reviewers prr WHERE prr.pr_id = f.id ORDER BY prr.assigned_at, prr.user_id),
+			(SELECT COUNT(*) FROM filtered) AS total_count
+		FROM filtered f
+		WHERE ` + after + `
+		ORDER BY ` + orderBy + `
+		LIMIT ` + arg(query.Limit+1)
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListPRs, args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	page := &domain.PullRequestPage{
+		Items: make([]domain.PullRequestDetails, 0, query.Limit),
+		Limit: query.Limit,
+	}
+	hasMore := false
+	for rows.Next() {
+		if len(page.Items) == query.Limit {
+			hasMore = true
+			break
+		}
+
+		var (
+			pr          domain.PullRequestDetails
+			reviewerIDs []string
+			assignedAt  []time.Time
+			states      []domain.ReviewState
+			reviewedAt  []*time.Time
+		)
+		err = rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+			&pr.Version,
+			&pr.RequiredReviewers,
+			&pr.Tags,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+			&pr.ClosedAt,
+			&reviewerIDs,
+			&assignedAt,
+			&states,
+			&reviewedAt,
+			&page.Total,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
+		}
+
+		pr.Reviewers = make([]domain.Reviewer, 0, len(reviewerIDs))
+		for i, reviewerID := range reviewerIDs {
+			pr.Reviewers = append(pr.Reviewers, domain.Reviewer{
+				UserID:     reviewerID,
+				AssignedAt: assignedAt[i],
+				State:      states[i],
+				ReviewedAt: reviewedAt[i],
+			})
+		}
+		page.Items = append(page.Items, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	// за последней страницей строк нет, и total из основного запроса не пришёл
+	if len(page.Items) == 0 && query.Cursor != nil {
+		qCount := `SELECT COUNT(*) FROM prs.pull_requests pr WHERE ` + where
+		if err := conn(ctx, repo.pool).QueryRow(ctx, qCount, args[:filterArgs]...).Scan(&page.Total); err != nil {
+			return nil, fmt.Errorf("count pull requests: %w", err)
+		}
+	}
+
+	if hasMore {
+		last := page.Items[len(page.Items)-1]
+		page.NextCursor = &domain.PullRequestCursor{
+			Sort:      query.Sort,
+			Desc:      query.Desc,
+			CreatedAt: last.CreatedAt,
+			ID:        last.PullRequestID,
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReviewerRuleRepository - правила исключения и обязательных ревьюверов для авторов (users.reviewer_rules)
+type ReviewerRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewReviewerRuleRepository - создает репозиторий правил подбора ревьюверов
+func NewReviewerRuleRepository(pool *pgxpool.Pool) *ReviewerRuleRepository {
+	return &ReviewerRuleRepository{pool: pool}
+}
+
+// Add сохраняет правило команды rule.TeamName и возвращает его с ID
+func (repo *ReviewerRuleRepository) Add(ctx context.Context, rule domain.ReviewerRule) (*domain.ReviewerRule, error) {
+	const qInsertRule = `
+		INSERT INTO users.reviewer_rules (team_id, rule_type, author_id, reviewer_id, tag)
+		SELECT t.id, $2, $3, $4, $5
+		FROM users.teams t
+		WHERE t.name = $1
+		RETURNING id, created_at
+	`
+
+	err := conn(ctx, repo.pool).QueryRow(ctx, qInsertRule,
+		rule.TeamName,
+		rule.Type,
+		rule.AuthorID,
+		rule.ReviewerID,
+		rule.Tag,
+	).Scan(&rule.ID, &rule.CreatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTeamNotFound
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &rule, nil
+}
+
+// ListByTeam возвращает правила команды в порядке добавления
+func (repo *ReviewerRuleRepository) ListByTeam(ctx context.Context, teamName string) ([]domain.ReviewerRule, error) {
+	const qListRules = `
+		SELECT r.id, t.name, r.rule_type, r.author_id, r.reviewer_id, r.tag, r.created_at
+		FROM users.reviewer_rules r
+		JOIN users.teams t ON t.id = r.team_id
+		WHERE t.name = $1
+		ORDER BY r.id
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListRules, teamName)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]domain.ReviewerRule, 0)
+	for rows.Next() {
+		var rule domain.ReviewerRule
+		err := rows.Scan(&rule.ID, &rule.TeamName, &rule.Type, &rule.AuthorID, &rule.ReviewerID, &rule.Tag, &rule.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// Delete удаляет правило команды
+func (repo *ReviewerRuleRepository) Delete(ctx context.Context, teamName string, id int64) error {
+	const qDeleteRule = `
+		DELETE FROM users.reviewer_rules r
+		USING users.teams t
+		WHERE r.team_id = t.id AND t.name = $1 AND r.id = $2
+	`
+
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qDeleteRule, teamName, id)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrReviewerRuleNotFound
+	}
+
+	return nil
+}
@@ -19,6 +19,7 @@ func NewStatisticsPostgresRepository(pool *pgxpool.Pool) *StatisticsPostgresRepo
 }
 
 // GetUserAssignmentStats возвращает статистику по пользователям с лимитом/оффсетом.
+// Назначения в закрытых без merge PR не учитываются.
 func (r *StatisticsPostgresRepository) GetUserAssignmentStats(
 	ctx context.Context,
 	limit, offset int,
@@ -39,7 +40,9 @@ func (r *StatisticsPostgresRepository) GetUserAssignmentStats(
 				u.name,
 				COUNT(*) AS assignments_count
 			FROM prs.pr_reviewers AS a
+			JOIN prs.pull_requests AS pr ON pr.id = a.pr_id
 			LEFT JOIN users.users AS u ON u.id = a.user_id
+			WHERE pr.status <> 'CLOSED'
 			GROUP BY a.user_id, u.name
 		)
 		SELECT
@@ -52,7 +55,7 @@ func (r *StatisticsPostgresRepository) GetUserAssignmentStats(
 		LIMIT $1 OFFSET $2;
 	`
 
-	rows, err := r.pool.Query(ctx, query, limit, offset)
+	rows, err := conn(ctx, r.pool).Query(ctx, query, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("query user stats: %w", err)
 	}
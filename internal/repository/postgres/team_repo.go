@@ -4,11 +4,107 @@ import (
 	"context"
 	"errors"
 	"pr-reviewer-assigment-service/internal/domain"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// teamSettingsColumns - колонки users.teams, из которых собирается domain.TeamSettings.
+// Порядок совпадает с teamSettingsDest.
+const teamSettingsColumns = `
+	required_reviewers,
+	selection_strategy,
+	load_metric,
+	load_window_hours,
+	load_half_life_hours,
+	required_approvals,
+	review_sla_hours,
+	auto_reassign_hours,
+	business_day_start,
+	business_day_end,
+	business_time_zone,
+	ARRAY(
+		SELECT ft.name
+		FROM users.team_fallbacks f
+		JOIN users.teams ft ON ft.id = f.fallback_team_id
+		WHERE f.team_id = teams.id
+		ORDER BY f.priority, ft.name
+	) AS fallback_teams`
+
+func teamSettingsDest(settings *domain.TeamSettings) []any {
+	return []any{
+		&settings.RequiredReviewers,
+		&settings.SelectionStrategy,
+		&settings.LoadMetric,
+		&settings.LoadWindowHours,
+		&settings.LoadHalfLifeHours,
+		&settings.RequiredApprovals,
+		&settings.ReviewSLAHours,
+		&settings.AutoReassignHours,
+		&settings.BusinessDayStart,
+		&settings.BusinessDayEnd,
+		&settings.BusinessTimeZone,
+		&settings.FallbackTeams,
+	}
+}
+
+// setFallbackTeams заменяет резервные команды teamID; порядок в names задаёт приоритет.
+func setFallbackTeams(ctx context.Context, q querier, teamID int64, names []string) error {
+	if _, err := q.Exec(ctx, `DELETE FROM users.team_fallbacks WHERE team_id = $1`, teamID); err != nil {
+		return err
+	}
+
+	const qInsertFallback = `
+		INSERT INTO users.team_fallbacks (team_id, fallback_team_id, priority)
+		SELECT $1, t.id, $3
+		FROM users.teams t
+		WHERE t.name = $2
+	`
+	for priority, name := range names {
+		cmdTag, err := q.Exec(ctx, qInsertFallback, teamID, name, priority)
+		if err != nil {
+			return err
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return domain.ErrTeamNotFound
+		}
+	}
+
+	return nil
+}
+
+// memberLoadExpr считает нагрузку участника u по метрике его команды t.
+// Закрытые без merge PR в нагрузку не входят.
+const memberLoadExpr = `
+	CASE t.load_metric
+		WHEN 'OPEN' THEN (
+			SELECT COUNT(*)
+			FROM prs.pr_reviewers pra
+			JOIN prs.pull_requests pr ON pr.id = pra.pr_id
+			WHERE pra.user_id = u.id AND pr.status = 'OPEN'
+		)::float8
+		WHEN 'WINDOW' THEN (
+			SELECT COUNT(*)
+			FROM prs.pr_reviewers pra
+			JOIN prs.pull_requests pr ON pr.id = pra.pr_id
+			WHERE pra.user_id = u.id AND pr.status <> 'CLOSED'
+			  AND pra.assigned_at >= NOW() - make_interval(hours => t.load_window_hours)
+		)::float8
+		WHEN 'DECAY' THEN (
+			SELECT COALESCE(SUM(POWER(0.5, EXTRACT(EPOCH FROM NOW() - pra.assigned_at) / 3600 / t.load_half_life_hours)), 0)
+			FROM prs.pr_reviewers pra
+			JOIN prs.pull_requests pr ON pr.id = pra.pr_id
+			WHERE pra.user_id = u.id AND pr.status <> 'CLOSED'
+		)::float8
+		ELSE (
+			SELECT COUNT(*)
+			FROM prs.pr_reviewers pra
+			JOIN prs.pull_requests pr ON pr.id = pra.pr_id
+			WHERE pra.user_id = u.id AND pr.status <> 'CLOSED'
+		)::float8
+	END`
+
 type TeamRepository struct {
 	pool *pgxpool.Pool
 }
@@ -18,7 +114,7 @@ func NewTeamRepository(pool *pgxpool.Pool) *TeamRepository {
 }
 
 func (repo *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) (err error) {
-	tx, err := repo.pool.Begin(ctx)
+	tx, err := begin(ctx, repo.pool)
 	if err != nil {
 		return err
 	}
@@ -35,13 +131,30 @@ func (repo *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) (
 	}()
 
 	const qCreateTeam = `
-		INSERT INTO users.teams (name)
-		VALUES ($1)
+		INSERT INTO users.teams (
+			name, selection_strategy, load_metric, load_window_hours, load_half_life_hours, required_reviewers, required_approvals,
+			review_sla_hours, auto_reassign_hours, business_day_start, business_day_end, business_time_zone
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7, 0), NULLIF($8, 0), NULLIF($9, 0), $10, $11, $12)
 		RETURNING id
 	`
 
 	var teamID int64
-	if err = tx.QueryRow(ctx, qCreateTeam, team.TeamName).Scan(&teamID); err != nil {
+	err = tx.QueryRow(ctx, qCreateTeam,
+		team.TeamName,
+		team.SelectionStrategy,
+		team.LoadMetric,
+		team.LoadWindowHours,
+		team.LoadHalfLifeHours,
+		team.RequiredReviewers,
+		team.RequiredApprovals,
+		team.ReviewSLAHours,
+		team.AutoReassignHours,
+		team.BusinessDayStart,
+		team.BusinessDayEnd,
+		team.BusinessTimeZone,
+	).Scan(&teamID)
+	if err != nil {
 		return err
 	}
 
@@ -56,7 +169,7 @@ func (repo *TeamRepository) CreateTeam(ctx context.Context, team *domain.Team) (
 		}
 	}
 
-	return nil
+	return setFallbackTeams(ctx, tx, teamID, team.FallbackTeams)
 }
 
 func (repo *TeamRepository) IsTeamExists(ctx context.Context, teamName string) (bool, error) {
@@ -70,7 +183,7 @@ func (repo *TeamRepository) IsTeamExists(ctx context.Context, teamName string) (
 			);
 	`
 	var exists bool
-	err := repo.pool.QueryRow(ctx, qExistsTeam, teamName).Scan(&exists)
+	err := conn(ctx, repo.pool).QueryRow(ctx, qExistsTeam, teamName).Scan(&exists)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return false, domain.ErrTeamNotFound
@@ -79,9 +192,10 @@ func (repo *TeamRepository) IsTeamExists(ctx context.Context, teamName string) (
 	return exists, nil
 }
 
-// UpdateTeamMembers - обновляет участников команды (UPSERT)
-func (repo *TeamRepository) UpdateTeamMembers(ctx context.Context, team *domain.Team) error {
-	tx, err := repo.pool.Begin(ctx)
+// UpdateTeamMembers - обновляет участников команды (UPSERT).
+// Непустые настройки команды перезаписываются, пустые остаются прежними.
+func (repo *TeamRepository) UpdateTeamMembers(ctx context.Context, team *domain.Team) (err error) {
+	tx, err := begin(ctx, repo.pool)
 	if err != nil {
 		return err
 	}
@@ -97,13 +211,38 @@ func (repo *TeamRepository) UpdateTeamMembers(ctx context.Context, team *domain.
 		}
 	}()
 
-	const qGetTeamID = `
-		SELECT id
-		FROM users.teams
+	const qUpdateTeam = `
+		UPDATE users.teams
+		SET selection_strategy = COALESCE(NULLIF($2, ''), selection_strategy),
+		    load_metric = COALESCE(NULLIF($3, ''), load_metric),
+		    load_window_hours = COALESCE(NULLIF($4, 0), load_window_hours),
+		    load_half_life_hours = COALESCE(NULLIF($5, 0), load_half_life_hours),
+		    required_reviewers = COALESCE(NULLIF($6, 0), required_reviewers),
+		    required_approvals = COALESCE($7, required_approvals),
+		    review_sla_hours = CASE WHEN $8::int IS NULL THEN review_sla_hours ELSE NULLIF($8, 0) END,
+		    auto_reassign_hours = CASE WHEN $9::int IS NULL THEN auto_reassign_hours ELSE NULLIF($9, 0) END,
+		    business_day_start = COALESCE($10, business_day_start),
+		    business_day_end = COALESCE($11, business_day_end),
+		    business_time_zone = COALESCE(NULLIF($12, ''), business_time_zone)
 		WHERE name = $1
+		RETURNING id
 	`
 	var teamID int64
-	if err = tx.QueryRow(ctx, qGetTeamID, team.TeamName).Scan(&teamID); err != nil {
+	err = tx.QueryRow(ctx, qUpdateTeam,
+		team.TeamName,
+		team.SelectionStrategy,
+		team.LoadMetric,
+		team.LoadWindowHours,
+		team.LoadHalfLifeHours,
+		team.RequiredReviewers,
+		team.RequiredApprovals,
+		team.ReviewSLAHours,
+		team.AutoReassignHours,
+		team.BusinessDayStart,
+		team.BusinessDayEnd,
+		team.BusinessTimeZone,
+	).Scan(&teamID)
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.ErrTeamNotFound
 		}
@@ -163,19 +302,27 @@ func (repo *TeamRepository) UpdateTeamMembers(ctx context.Context, team *domain.
 		}
 	}
 
+	// nil - резервные команды не переданы и остаются прежними
+	if team.FallbackTeams != nil {
+		return setFallbackTeams(ctx, tx, teamID, team.FallbackTeams)
+	}
+
 	return nil
 }
 
 // GetTeam возвращает полную информацию о команде и её участников
 func (repo *TeamRepository) GetTeam(ctx context.Context, teamName string) (*domain.Team, error) {
 	const qSelectTeam = `
-		SELECT id
+		SELECT id, ` + teamSettingsColumns + `
 		FROM users.teams
 		WHERE name = $1
 	`
 
-	var teamID int64
-	err := repo.pool.QueryRow(ctx, qSelectTeam, teamName).Scan(&teamID)
+	var (
+		teamID   int64
+		settings domain.TeamSettings
+	)
+	err := conn(ctx, repo.pool).QueryRow(ctx, qSelectTeam, teamName).Scan(append([]any{&teamID}, teamSettingsDest(&settings)...)...)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrTeamNotFound
@@ -184,14 +331,15 @@ func (repo *TeamRepository) GetTeam(ctx context.Context, teamName string) (*doma
 	}
 
 	const qSelectMembers = `
-		SELECT u.id, u.name, u.is_active
+		SELECT u.id, u.name, u.is_active, u.max_open_reviews, u.tags, ` + memberLoadExpr + ` AS load
 		FROM users.team_members tm
+		JOIN users.teams t ON t.id = tm.team_id
 		JOIN users.users u ON u.id = tm.user_id
 		WHERE tm.team_id = $1
 		ORDER BY u.name
 	`
 
-	rows, err := repo.pool.Query(ctx, qSelectMembers, teamID)
+	rows, err := conn(ctx, repo.pool).Query(ctx, qSelectMembers, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -200,7 +348,7 @@ func (repo *TeamRepository) GetTeam(ctx context.Context, teamName string) (*doma
 	var members []domain.Member
 	for rows.Next() {
 		var m domain.Member
-		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive); err != nil {
+		if err := rows.Scan(&m.UserID, &m.Username, &m.IsActive, &m.MaxOpenReviews, &m.Tags, &m.Load); err != nil {
 			return nil, err
 		}
 		members = append(members, m)
@@ -211,12 +359,53 @@ func (repo *TeamRepository) GetTeam(ctx context.Context, teamName string) (*doma
 	}
 
 	return &domain.Team{
-		TeamName: teamName,
-		Members:  members,
+		TeamName:     teamName,
+		Members:      members,
+		TeamSettings: settings,
 	}, nil
 }
 
-// GetTeamsMembersByTeamName - возвращает участников команды по её названию
+// LockTeams блокирует строки команд teamNames до конца транзакции из ctx в порядке имён,
+// чтобы параллельные назначения с общими командами видели согласованную нагрузку и не взаимоблокировались.
+// Несуществующие команды пропускаются.
+func (repo *TeamRepository) LockTeams(ctx context.Context, teamNames []string) error {
+	const qLockTeams = `
+		SELECT id
+		FROM users.teams
+		WHERE name = ANY($1)
+		ORDER BY name
+		FOR NO KEY UPDATE
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qLockTeams, teamNames)
+	if err != nil {
+		return err
+	}
+	rows.Close()
+	return rows.Err()
+}
+
+// GetTeamSettings возвращает настройки назначения ревьюверов для команды
+func (repo *TeamRepository) GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error) {
+	const qSelectSettings = `
+		SELECT ` + teamSettingsColumns + `
+		FROM users.teams
+		WHERE name = $1
+	`
+
+	var settings domain.TeamSettings
+	err := conn(ctx, repo.pool).QueryRow(ctx, qSelectSettings, teamName).Scan(teamSettingsDest(&settings)...)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTeamNotFound
+		}
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// GetTeamsMembersByTeamName - возвращает активных участников команды, кроме тех, кто сейчас в отпуске (users.unavailability)
 func (repo *TeamRepository) GetTeamsMembersByTeamName(ctx context.Context, teamName string) ([]domain.Member, error) {
 	const qSelectTeamID = `
         SELECT id
@@ -225,7 +414,7 @@ func (repo *TeamRepository) GetTeamsMembersByTeamName(ctx context.Context, teamN
     `
 
 	var teamID int64
-	err := repo.pool.QueryRow(ctx, qSelectTeamID, teamName).Scan(&teamID)
+	err := conn(ctx, repo.pool).QueryRow(ctx, qSelectTeamID, teamName).Scan(&teamID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrTeamNotFound
@@ -241,15 +430,36 @@ func (repo *TeamRepository) GetTeamsMembersByTeamName(ctx context.Context, teamN
             (
                 SELECT COUNT(*)
                 FROM prs.pr_reviewers pra
+                JOIN prs.pull_requests pr ON pr.id = pra.pr_id
+                WHERE pra.user_id = u.id AND pr.status <> 'CLOSED'
+            ) AS pr_reviews,
+            (
+                SELECT COUNT(*)
+                FROM prs.pr_reviewers pra
+                JOIN prs.pull_requests pr ON pr.id = pra.pr_id
+                WHERE pra.user_id = u.id AND pr.status = 'OPEN'
+            ) AS open_reviews,
+            (
+                SELECT MAX(pra.assigned_at)
+                FROM prs.pr_reviewers pra
                 WHERE pra.user_id = u.id
-            ) AS pr_reviews
+            ) AS last_assigned_at,
+            ` + memberLoadExpr + ` AS load,
+            u.max_open_reviews,
+            u.tags
         FROM users.team_members tm
+        JOIN users.teams t ON t.id = tm.team_id
         JOIN users.users u ON u.id = tm.user_id
         WHERE tm.team_id = $1 AND u.is_active = true
+          AND NOT EXISTS (
+              SELECT 1
+              FROM users.unavailability ua
+              WHERE ua.user_id = u.id AND ua.starts_at <= NOW() AND ua.ends_at > NOW()
+          )
         ORDER BY u.name;
     `
 
-	rows, err := repo.pool.Query(ctx, qSelectMembers, teamID)
+	rows, err := conn(ctx, repo.pool).Query(ctx, qSelectMembers, teamID)
 	if err != nil {
 		return nil, err
 	}
@@ -259,22 +469,33 @@ func (repo *TeamRepository) GetTeamsMembersByTeamName(ctx context.Context, teamN
 
 	for rows.Next() {
 		var (
-			id        string
-			username  string
-			isActive  bool
-			prReviews int64
+			id             string
+			username       string
+			isActive       bool
+			prReviews      int64
+			openReviews    int64
+			lastAssignedAt *time.Time
+			load           float64
+			maxOpenReviews *int
+			tags           []string
 		)
 
-		if err := rows.Scan(&id, &username, &isActive, &prReviews); err != nil {
+		if err := rows.Scan(&id, &username, &isActive, &prReviews, &openReviews, &lastAssignedAt, &load, &maxOpenReviews, &tags); err != nil {
 			return nil, err
 		}
 
 		prPtr := prReviews
+		openPtr := openReviews
 		members = append(members, domain.Member{
-			UserID:    id,
-			Username:  username,
-			IsActive:  isActive,
-			PRReviews: &prPtr,
+			UserID:         id,
+			Username:       username,
+			IsActive:       isActive,
+			PRReviews:      &prPtr,
+			OpenReviews:    &openPtr,
+			LastAssignedAt: lastAssignedAt,
+			Load:           &load,
+			MaxOpenReviews: maxOpenReviews,
+			Tags:           tags,
 		})
 	}
 
@@ -284,3 +505,59 @@ func (repo *TeamRepository) GetTeamsMembersByTeamName(ctx context.Context, teamN
 
 	return members, nil
 }
+
+// DeactivateMembers деактивирует участников команды teamName из userIDs. Возвращает идентификаторы тех,
+// кто действительно состоит в команде, и тех из них, кто был активен до вызова.
+func (repo *TeamRepository) DeactivateMembers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]string, []string, error) {
+	const qDeactivateMembers = `
+		WITH members AS (
+			SELECT u.id, u.is_active
+			FROM users.users u
+			JOIN users.team_members tm ON tm.user_id = u.id
+			JOIN users.teams t ON t.id = tm.team_id
+			WHERE t.name = $1
+			  AND u.id = ANY($2)
+			FOR UPDATE OF u
+		), deactivated AS (
+			UPDATE users.users u
+			SET is_active = false
+			FROM members m
+			WHERE u.id = m.id
+			  AND m.is_active
+		)
+		SELECT id, is_active
+		FROM members
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qDeactivateMembers, teamName, userIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	members := make([]string, 0, len(userIDs))
+	deactivated := make([]string, 0, len(userIDs))
+	for rows.Next() {
+		var (
+			userID    string
+			wasActive bool
+		)
+		if err := rows.Scan(&userID, &wasActive); err != nil {
+			return nil, nil, err
+		}
+		members = append(members, userID)
+		if wasActive {
+			deactivated = append(deactivated, userID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return members, deactivated, nil
+}
@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// querier - общее подмножество pgxpool.Pool и pgx.Tx, которым пользуются репозитории.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// conn возвращает транзакцию из контекста, если она открыта через Transactor, иначе пул.
+func conn(ctx context.Context, pool *pgxpool.Pool) querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return pool
+}
+
+// begin открывает транзакцию, а внутри уже открытой - savepoint.
+func begin(ctx context.Context, pool *pgxpool.Pool) (pgx.Tx, error) {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx.Begin(ctx)
+	}
+	return pool.Begin(ctx)
+}
+
+// Transactor - unit of work поверх pgxpool: все вызовы репозиториев
+// с контекстом из WithinTransaction выполняются в одной транзакции.
+type Transactor struct {
+	pool *pgxpool.Pool
+}
+
+// NewTransactor создаёт Transactor поверх пула соединений.
+func NewTransactor(pool *pgxpool.Pool) *Transactor {
+	return &Transactor{pool: pool}
+}
+
+// WithinTransaction выполняет fn в транзакции: коммитит, если fn вернула nil, иначе откатывает.
+// Вложенный вызов работает через savepoint и откатывает только свою часть.
+func (t *Transactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	tx, err := begin(ctx, t.pool)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		} else if err != nil {
+			_ = tx.Rollback(ctx)
+		} else {
+			err = tx.Commit(ctx)
+		}
+	}()
+
+	return fn(context.WithValue(ctx, txKey{}, tx))
+}
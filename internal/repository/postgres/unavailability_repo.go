@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// foreignKeyViolation - SQLSTATE нарушения внешнего ключа.
+const foreignKeyViolation = "23503"
+
+// uniqueViolation - SQLSTATE нарушения уникальности.
+const uniqueViolation = "23505"
+
+// UnavailabilityRepository - периоды отсутствия пользователей (users.unavailability)
+type UnavailabilityRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewUnavailabilityRepository - создает репозиторий периодов отсутствия
+func NewUnavailabilityRepository(pool *pgxpool.Pool) *UnavailabilityRepository {
+	return &UnavailabilityRepository{pool: pool}
+}
+
+// Add сохраняет период отсутствия и возвращает его с ID
+func (repo *UnavailabilityRepository) Add(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error) {
+	const qInsertUnavailability = `
+		INSERT INTO users.unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`
+
+	err := conn(ctx, repo.pool).QueryRow(ctx, qInsertUnavailability,
+		period.UserID,
+		period.StartsAt,
+		period.EndsAt,
+		period.Reason,
+	).Scan(&period.ID, &period.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &period, nil
+}
+
+// ListByUser возвращает периоды отсутствия пользователя, которые ещё не закончились, по времени начала
+func (repo *UnavailabilityRepository) ListByUser(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	const qListUnavailability = `
+		SELECT id, user_id, starts_at, ends_at, reason, created_at
+		FROM users.unavailability
+		WHERE user_id = $1 AND ends_at > NOW()
+		ORDER BY starts_at, id
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListUnavailability, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	periods := make([]domain.Unavailability, 0)
+	for rows.Next() {
+		var period domain.Unavailability
+		err := rows.Scan(&period.ID, &period.UserID, &period.StartsAt, &period.EndsAt, &period.Reason, &period.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, period)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+// Delete удаляет период отсутствия пользователя
+func (repo *UnavailabilityRepository) Delete(ctx context.Context, userID string, id int64) error {
+	const qDeleteUnavailability = `
+		DELETE FROM users.unavailability
+		WHERE id = $1 AND user_id = $2
+	`
+
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qDeleteUnavailability, id, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrUnavailabilityNotFound
+	}
+
+	return nil
+}
@@ -26,7 +26,9 @@ func (repo *UserRepository) GetByID(ctx context.Context, id string) (*domain.Use
 			u.id as user_id,
 			u.name as username,
 			u.is_active,
-			t.name as team_name
+			t.name as team_name,
+			u.max_open_reviews,
+			u.tags
 		FROM users.users u
 		LEFT JOIN users.team_members tm ON tm.user_id = u.id
 		LEFT JOIN users.teams t ON t.id = tm.team_id 
@@ -36,7 +38,7 @@ func (repo *UserRepository) GetByID(ctx context.Context, id string) (*domain.Use
 
 	user := &domain.User{}
 
-	err := repo.pool.QueryRow(ctx, qGetUserByID, id).Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName)
+	err := conn(ctx, repo.pool).QueryRow(ctx, qGetUserByID, id).Scan(&user.ID, &user.Username, &user.IsActive, &user.TeamName, &user.MaxOpenReviews, &user.Tags)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrUserNotFound
@@ -55,7 +57,29 @@ func (repo *UserRepository) UpdateActive(ctx context.Context, user *domain.User)
 		WHERE id = $2
 	`
 
-	cmdTag, err := repo.pool.Exec(ctx, qUpdateActiveByID, user.IsActive, user.ID)
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qUpdateActiveByID, user.IsActive, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if cmdTag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	return nil
+}
+
+// Update обновляет имя, лимит открытых ревью и теги юзера
+func (repo *UserRepository) Update(ctx context.Context, user *domain.User) error {
+	const qUpdateUser = `
+		UPDATE users.users
+		SET name = $2,
+		    max_open_reviews = $3,
+		    tags = COALESCE($4, '{}')
+		WHERE id = $1
+	`
+
+	cmdTag, err := conn(ctx, repo.pool).Exec(ctx, qUpdateUser, user.ID, user.Username, user.MaxOpenReviews, user.Tags)
 	if err != nil {
 		return err
 	}
@@ -77,7 +101,7 @@ func (repo *UserRepository) Create(ctx context.Context, userID, name string, isA
 
 	var u domain.User
 
-	err := repo.pool.QueryRow(ctx, q, userID, name, isActive).Scan(
+	err := conn(ctx, repo.pool).QueryRow(ctx, q, userID, name, isActive).Scan(
 		&u.ID,
 		&u.Username,
 		&u.IsActive,
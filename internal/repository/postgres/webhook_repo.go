@@ -0,0 +1,282 @@
+package postgres
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WebhookRepository - подписки на вебхуки и outbox их доставок (prs.webhook_subscriptions, prs.webhook_deliveries)
+type WebhookRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookRepository - создает репозиторий вебхуков
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{pool: pool}
+}
+
+// CreateSubscription сохраняет подписку и возвращает её с ID
+func (repo *WebhookRepository) CreateSubscription(
+	ctx context.Context,
+	subscription domain.WebhookSubscription,
+) (*domain.WebhookSubscription, error) {
+	const qInsertSubscription = `
+		INSERT INTO prs.webhook_subscriptions (url, secret, event_types)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	eventTypes := make([]string, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	err := conn(ctx, repo.pool).QueryRow(ctx, qInsertSubscription,
+		subscription.URL,
+		subscription.Secret,
+		eventTypes,
+	).Scan(&subscription.ID, &subscription.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+// ListSubscriptions возвращает все подписки в порядке создания
+func (repo *WebhookRepository) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	const qListSubscriptions = `
+		SELECT id, url, secret, event_types, created_at
+		FROM prs.webhook_subscriptions
+		ORDER BY id
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListSubscriptions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]domain.WebhookSubscription, 0)
+	for rows.Next() {
+		var (
+			subscription domain.WebhookSubscription
+			eventTypes   []string
+		)
+		err := rows.Scan(&subscription.ID, &subscription.URL, &subscription.Secret, &eventTypes, &subscription.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		for _, eventType := range eventTypes {
+			subscription.EventTypes = append(subscription.EventTypes, domain.AssignmentEventType(eventType))
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription удаляет подписку вместе с её доставками
+func (repo *WebhookRepository) DeleteSubscription(ctx context.Context, id int64) error {
+	const qDeleteSubscription = `
+		DELETE FROM prs.webhook_subscriptions
+		WHERE id = $1
+	`
+
+	tag, err := conn(ctx, repo.pool).Exec(ctx, qDeleteSubscription, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+// Enqueue записывает доставки в outbox; внутри транзакции - вместе с событиями, которые они доставляют
+func (repo *WebhookRepository) Enqueue(ctx context.Context, deliveries []domain.WebhookDelivery) error {
+	const qInsertDelivery = `
+		INSERT INTO prs.webhook_deliveries (subscription_id, event_id, event_type, payload)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (subscription_id, event_id) DO NOTHING
+	`
+
+	q := conn(ctx, repo.pool)
+	for _, delivery := range deliveries {
+		_, err := q.Exec(ctx, qInsertDelivery,
+			delivery.SubscriptionID,
+			delivery.EventID,
+			delivery.EventType,
+			delivery.Payload,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ClaimDue выбирает до limit доставок, чья попытка наступила к now, и откладывает их на lease,
+// чтобы другие экземпляры сервиса не отправили их параллельно; упорядочены по времени попытки
+func (repo *WebhookRepository) ClaimDue(
+	ctx context.Context,
+	now time.Time,
+	lease time.Duration,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	const qClaimDue = `
+		WITH claimed AS (
+			UPDATE prs.webhook_deliveries d
+			SET next_attempt_at = $2
+			FROM (
+				SELECT id
+				FROM prs.webhook_deliveries
+				WHERE status = 'PENDING' AND next_attempt_at <= $1
+				ORDER BY next_attempt_at, id
+				LIMIT $3
+				FOR UPDATE SKIP LOCKED
+			) due
+			WHERE d.id = due.id
+			RETURNING d.*
+		)
+		SELECT
+			c.id,
+			c.subscription_id,
+			s.url,
+			s.secret,
+			c.event_id,
+			c.event_type,
+			c.payload,
+			c.status,
+			c.attempts,
+			c.next_attempt_at,
+			c.last_attempt_at,
+			c.last_error,
+			c.created_at,
+			c.delivered_at
+		FROM claimed c
+		JOIN prs.webhook_subscriptions s ON s.id = c.subscription_id
+		ORDER BY c.id
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qClaimDue, now, now.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		var delivery domain.WebhookDelivery
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.URL,
+			&delivery.Secret,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastAttemptAt,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.DeliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// SaveAttempt сохраняет результат попытки доставки
+func (repo *WebhookRepository) SaveAttempt(ctx context.Context, delivery domain.WebhookDelivery) error {
+	const qUpdateDelivery = `
+		UPDATE prs.webhook_deliveries
+		SET status = $2,
+			attempts = $3,
+			next_attempt_at = $4,
+			last_attempt_at = $5,
+			last_error = $6,
+			delivered_at = $7
+		WHERE id = $1
+	`
+
+	_, err := conn(ctx, repo.pool).Exec(ctx, qUpdateDelivery,
+		delivery.ID,
+		delivery.Status,
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastAttemptAt,
+		delivery.LastError,
+		delivery.DeliveredAt,
+	)
+	return err
+}
+
+// ListDeadLetters возвращает доставки, исчерпавшие попытки (prs.webhook_dead_letters), от новых к старым;
+// subscriptionID = nil - по всем подпискам
+func (repo *WebhookRepository) ListDeadLetters(
+	ctx context.Context,
+	subscriptionID *int64,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	const qListDeadLetters = `
+		SELECT id, subscription_id, url, event_id, event_type, payload, attempts, last_error, created_at, last_attempt_at
+		FROM prs.webhook_dead_letters
+		WHERE $1::bigint IS NULL OR subscription_id = $1
+		ORDER BY id DESC
+		LIMIT $2
+	`
+
+	rows, err := conn(ctx, repo.pool).Query(ctx, qListDeadLetters, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]domain.WebhookDelivery, 0)
+	for rows.Next() {
+		delivery := domain.WebhookDelivery{Status: domain.WebhookDead}
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.SubscriptionID,
+			&delivery.URL,
+			&delivery.EventID,
+			&delivery.EventType,
+			&delivery.Payload,
+			&delivery.Attempts,
+			&delivery.LastError,
+			&delivery.CreatedAt,
+			&delivery.LastAttemptAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,102 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"pr-reviewer-assigment-service/internal/domain"
+	"time"
+)
+
+// Leader решает, какой из экземпляров сервиса выполняет фоновые задачи.
+type Leader interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+// StaleReviewReassigner переназначает ревью, которые слишком долго ждут вердикта.
+type StaleReviewReassigner interface {
+	ReassignStaleReviews(ctx context.Context, now time.Time, dryRun bool) ([]domain.ReassignmentOutcome, error)
+}
+
+// Scheduler периодически переназначает зависшие ревью. Задачу выполняет только лидер,
+// поэтому при нескольких экземплярах сервиса одно ревью не переназначается дважды.
+type Scheduler struct {
+	leader     Leader
+	reassigner StaleReviewReassigner
+	interval   time.Duration
+	dryRun     bool
+	now        func() time.Time
+}
+
+// New создаёт планировщик с периодом interval. В режиме dryRun переназначения только логируются.
+func New(leader Leader, reassigner StaleReviewReassigner, interval time.Duration, dryRun bool) *Scheduler {
+	return &Scheduler{
+		leader:     leader,
+		reassigner: reassigner,
+		interval:   interval,
+		dryRun:     dryRun,
+		now:        time.Now,
+	}
+}
+
+// Run выполняет задачу раз в interval до отмены ctx, после чего отпускает лидерство.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer func() {
+		if err := s.leader.Release(context.WithoutCancel(ctx)); err != nil {
+			log.Printf("scheduler: failed to release leadership: %v", err)
+		}
+	}()
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick(ctx)
+		}
+	}
+}
+
+// tick выполняет один проход, если экземпляр - лидер.
+func (s *Scheduler) tick(ctx context.Context) {
+	isLeader, err := s.leader.TryAcquire(ctx)
+	if err != nil {
+		log.Printf("scheduler: leader election failed: %v", err)
+		return
+	}
+	if !isLeader {
+		return
+	}
+
+	outcomes, err := s.reassigner.ReassignStaleReviews(ctx, s.now(), s.dryRun)
+	if err != nil {
+		log.Printf("scheduler: stale review reassignment failed: %v", err)
+		return
+	}
+
+	prefix := "scheduler:"
+	if s.dryRun {
+		prefix = "scheduler: dry-run:"
+	}
+	for _, outcome := range outcomes {
+		if outcome.Status == domain.ReassignmentFailed {
+			log.Printf("%s %s: stale review of %s not reassigned: %s: %s",
+				prefix, outcome.PullRequestID, outcome.ReplacedUserID, outcome.Status, outcome.Error)
+			continue
+		}
+		if outcome.Status != domain.ReassignmentDone {
+			log.Printf("%s %s: stale review of %s not reassigned: %s",
+				prefix, outcome.PullRequestID, outcome.ReplacedUserID, outcome.Status)
+			continue
+		}
+		newReviewerID := "-"
+		if outcome.NewReviewerID != nil {
+			newReviewerID = *outcome.NewReviewerID
+		}
+		log.Printf("%s %s: stale review of %s reassigned to %s",
+			prefix, outcome.PullRequestID, outcome.ReplacedUserID, newReviewerID)
+	}
+}
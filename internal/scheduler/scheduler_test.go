@@ -0,0 +1,81 @@
+package scheduler_test
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/scheduler"
+	"testing"
+	"time"
+)
+
+type fakeLeader struct {
+	isLeader bool
+	attempts int
+	released bool
+	// onAttempt вызывается после каждой попытки захвата
+	onAttempt func()
+}
+
+func (l *fakeLeader) TryAcquire(context.Context) (bool, error) {
+	l.attempts++
+	if l.onAttempt != nil {
+		l.onAttempt()
+	}
+	return l.isLeader, nil
+}
+
+func (l *fakeLeader) Release(context.Context) error {
+	l.released = true
+	return nil
+}
+
+type fakeReassigner struct {
+	calls  int
+	dryRun bool
+	cancel context.CancelFunc
+}
+
+func (r *fakeReassigner) ReassignStaleReviews(_ context.Context, _ time.Time, dryRun bool) ([]domain.ReassignmentOutcome, error) {
+	r.calls++
+	r.dryRun = dryRun
+	r.cancel()
+	return []domain.ReassignmentOutcome{{PullRequestID: "pr-1", ReplacedUserID: "u1", Status: domain.ReassignmentNoCandidate}}, nil
+}
+
+func TestSchedulerRunsOnlyOnLeader(t *testing.T) {
+	t.Run("leader", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		leader := &fakeLeader{isLeader: true}
+		reassigner := &fakeReassigner{cancel: cancel}
+
+		scheduler.New(leader, reassigner, time.Millisecond, true).Run(ctx)
+
+		if reassigner.calls != 1 || !reassigner.dryRun {
+			t.Errorf("reassigner calls = %d, dryRun = %v; want 1, true", reassigner.calls, reassigner.dryRun)
+		}
+		if !leader.released {
+			t.Error("leadership not released on exit")
+		}
+	})
+
+	t.Run("follower", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		leader := &fakeLeader{}
+		leader.onAttempt = func() {
+			if leader.attempts == 3 {
+				cancel()
+			}
+		}
+		reassigner := &fakeReassigner{cancel: cancel}
+
+		scheduler.New(leader, reassigner, time.Millisecond, false).Run(ctx)
+
+		if reassigner.calls != 0 {
+			t.Errorf("reassigner calls = %d, want 0", reassigner.calls)
+		}
+	})
+}
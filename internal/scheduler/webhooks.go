@@ -0,0 +1,58 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// WebhookDeliverer отправляет наступившие доставки вебхуков.
+type WebhookDeliverer interface {
+	DeliverDue(ctx context.Context, limit int) (int, error)
+}
+
+// WebhookWorker периодически разбирает outbox вебхуков. Доставки выбираются с блокировкой
+// на время отправки, поэтому воркер может работать на всех экземплярах сервиса без выбора лидера.
+type WebhookWorker struct {
+	deliverer WebhookDeliverer
+	interval  time.Duration
+	batchSize int
+}
+
+// NewWebhookWorker создаёт воркер, который раз в interval отправляет доставки пачками по batchSize.
+func NewWebhookWorker(deliverer WebhookDeliverer, interval time.Duration, batchSize int) *WebhookWorker {
+	return &WebhookWorker{
+		deliverer: deliverer,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+// Run разбирает outbox раз в interval до отмены ctx.
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.tick(ctx)
+		}
+	}
+}
+
+// tick отправляет пачки, пока наступившие доставки не закончатся.
+func (w *WebhookWorker) tick(ctx context.Context) {
+	for ctx.Err() == nil {
+		delivered, err := w.deliverer.DeliverDue(ctx, w.batchSize)
+		if err != nil {
+			log.Printf("webhooks: delivery failed: %v", err)
+			return
+		}
+		if delivered < w.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package service
+
+import "context"
+
+type actorKey struct{}
+
+// WithActor сохраняет в ctx идентификатор пользователя, выполняющего операцию.
+// Он попадает в историю назначений; пустой идентификатор означает системное действие.
+func WithActor(ctx context.Context, actorID string) context.Context {
+	if actorID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, actorKey{}, actorID)
+}
+
+// actorFromContext возвращает идентификатор пользователя из WithActor или nil.
+func actorFromContext(ctx context.Context) *string {
+	if actorID, ok := ctx.Value(actorKey{}).(string); ok {
+		return &actorID
+	}
+	return nil
+}
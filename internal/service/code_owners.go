@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+)
+
+// CodeOwnerRepository хранит правила владения путями (CODEOWNERS) команд.
+type CodeOwnerRepository interface {
+	ListByTeam(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error)
+	Replace(ctx context.Context, teamName string, rules []domain.CodeOwnerRule) error
+}
+
+// SetCodeOwners заменяет правила владения путями команды teamName; порядок правил важен,
+// для файла действует последнее подходящее правило.
+func (service *TeamService) SetCodeOwners(
+	ctx context.Context,
+	teamName string,
+	rules []domain.CodeOwnerRule,
+) ([]domain.CodeOwnerRule, error) {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := service.codeOwnerRepo.Replace(ctx, teamName, rules); err != nil {
+		return nil, err
+	}
+
+	return service.codeOwnerRepo.ListByTeam(ctx, teamName)
+}
+
+// GetCodeOwners возвращает правила владения путями команды в порядке их задания.
+func (service *TeamService) GetCodeOwners(ctx context.Context, teamName string) ([]domain.CodeOwnerRule, error) {
+	isTeamExists, err := service.teamRepo.IsTeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !isTeamExists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return service.codeOwnerRepo.ListByTeam(ctx, teamName)
+}
+
+// pickCodeOwners выбирает ревьюверами владельцев изменённых файлов по правилам команды teamName.
+// Для каждого файла действует последнее подходящее правило, как в CODEOWNERS. Владелец-команда
+// представлена одним участником, выбранным стратегией из settings; если кто-то из её участников
+// уже выбран, команда считается покрытой. Владельцы из exclude, неактивные, отсутствующие и
+// исчерпавшие лимит пропускаются - их места заполнит обычный выбор. Выбранные добавляются в exclude.
+func (service *PullRequestService) pickCodeOwners(
+	ctx context.Context,
+	members teamMembersFunc,
+	teamName string,
+	settings *domain.TeamSettings,
+	changedFiles []string,
+	exclude map[string]struct{},
+) ([]domain.CodeOwnerReviewer, error) {
+	if len(changedFiles) == 0 {
+		return nil, nil
+	}
+
+	rules, err := service.codeOwnerRepo.ListByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	matched := matchCodeOwnerRules(rules, changedFiles)
+	if len(matched) == 0 {
+		return nil, nil
+	}
+
+	selector, err := NewReviewerSelector(settings.SelectionStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []domain.CodeOwnerReviewer
+	picked := make(map[string]struct{})
+	for _, rule := range matched {
+		var ownerTeam string
+		if rule.OwnerUserID != nil {
+			if _, ok := picked[*rule.OwnerUserID]; ok {
+				continue
+			}
+			owner, err := service.userRepo.GetByID(ctx, *rule.OwnerUserID)
+			if err != nil {
+				return nil, err
+			}
+			if owner.TeamName == nil {
+				continue
+			}
+			ownerTeam = *owner.TeamName
+		} else {
+			ownerTeam = *rule.OwnerTeam
+		}
+
+		teamMembers, err := members(ctx, ownerTeam)
+		if err != nil {
+			if errors.Is(err, domain.ErrTeamNotFound) {
+				continue
+			}
+			return nil, err
+		}
+
+		candidates := make([]domain.Member, 0, len(teamMembers))
+		covered := false
+		for _, member := range teamMembers {
+			if _, ok := picked[member.UserID]; ok {
+				covered = true
+			}
+			if rule.OwnerUserID != nil && member.UserID != *rule.OwnerUserID || !isCandidate(member, exclude) {
+				continue
+			}
+			candidates = append(candidates, member)
+		}
+		if rule.OwnerTeam != nil && covered {
+			continue
+		}
+
+		selected := selector.Select(candidates, 1)
+		if len(selected) == 0 {
+			continue
+		}
+
+		ownerID := selected[0].UserID
+		picked[ownerID] = struct{}{}
+		exclude[ownerID] = struct{}{}
+		owners = append(owners, domain.CodeOwnerReviewer{UserID: ownerID, Pattern: rule.Pattern})
+	}
+
+	return owners, nil
+}
+
+// codeOwnerTeams возвращает команды, из которых pickCodeOwners выбирает владельцев изменённых файлов
+// по правилам команды teamName.
+func (service *PullRequestService) codeOwnerTeams(ctx context.Context, teamName string, changedFiles []string) ([]string, error) {
+	if len(changedFiles) == 0 {
+		return nil, nil
+	}
+
+	rules, err := service.codeOwnerRepo.ListByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	var teams []string
+	for _, rule := range matchCodeOwnerRules(rules, changedFiles) {
+		if rule.OwnerTeam != nil {
+			teams = append(teams, *rule.OwnerTeam)
+			continue
+		}
+		owner, err := service.userRepo.GetByID(ctx, *rule.OwnerUserID)
+		if err != nil {
+			return nil, err
+		}
+		if owner.TeamName != nil {
+			teams = append(teams, *owner.TeamName)
+		}
+	}
+	return teams, nil
+}
+
+// matchCodeOwnerRules возвращает правила, которые владеют хотя бы одним из файлов,
+// в порядке первого затронутого файла. Для файла действует последнее подходящее правило.
+func matchCodeOwnerRules(rules []domain.CodeOwnerRule, files []string) []domain.CodeOwnerRule {
+	var matched []domain.CodeOwnerRule
+	seen := make(map[int]struct{})
+	for _, file := range files {
+		for i := len(rules) - 1; i >= 0; i-- {
+			if !rules[i].Matches(file) {
+				continue
+			}
+			if _, ok := seen[i]; !ok {
+				seen[i] = struct{}{}
+				matched = append(matched, rules[i])
+			}
+			break
+		}
+	}
+	return matched
+}
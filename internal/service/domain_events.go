@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+	"time"
+)
+
+// DomainEventRepository - outbox доменных событий, из которого внешние потребители читают поток изменений.
+type DomainEventRepository interface {
+	Append(ctx context.Context, events []domain.DomainEvent) error
+	ListAfter(ctx context.Context, after domain.EventCursor, limit int) ([]domain.DomainEvent, error)
+	Lag(ctx context.Context, after domain.EventCursor) (domain.EventLag, error)
+}
+
+// EventNotifier сообщает о записи новых событий.
+type EventNotifier interface {
+	// Changed возвращает канал, который закроется при следующей записи событий.
+	Changed() <-chan struct{}
+}
+
+// eventsPollInterval - как часто ожидающий перечитывает outbox без уведомления: событие может стать
+// видимым позже записи, когда завершится более ранняя транзакция.
+const eventsPollInterval = time.Second
+
+type EventService struct {
+	repo     DomainEventRepository
+	notifier EventNotifier
+}
+
+func NewEventService(repo DomainEventRepository, notifier EventNotifier) *EventService {
+	return &EventService{
+		repo:     repo,
+		notifier: notifier,
+	}
+}
+
+// Poll возвращает до limit событий после курсора after. Если их пока нет, ждёт новых до wait
+// и возвращает пустой список, если они так и не появились.
+func (service *EventService) Poll(
+	ctx context.Context,
+	after domain.EventCursor,
+	limit int,
+	wait time.Duration,
+) ([]domain.DomainEvent, error) {
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(eventsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		// канал берётся до чтения, чтобы не пропустить запись между чтением и ожиданием
+		changed := service.notifier.Changed()
+
+		events, err := service.repo.ListAfter(ctx, after, limit)
+		if err != nil || len(events) > 0 {
+			return events, err
+		}
+
+		select {
+		case <-changed:
+		case <-ticker.C:
+		case <-deadline.C:
+			return events, nil
+		case <-ctx.Done():
+			return events, nil
+		}
+	}
+}
+
+// Lag сообщает, сколько закоммиченных событий после курсора after задержано открытой более ранней
+// транзакцией. Пока такая транзакция не завершится, Poll их не вернёт.
+func (service *EventService) Lag(ctx context.Context, after domain.EventCursor) (domain.EventLag, error) {
+	return service.repo.Lag(ctx, after)
+}
+
+// pullRequestEventPayload - описание событий PR и его ревьюверов.
+type pullRequestEventPayload struct {
+	PullRequestID   string  `json:"pull_request_id"`
+	PullRequestName string  `json:"pull_request_name,omitempty"`
+	AuthorID        string  `json:"author_id,omitempty"`
+	ReviewerID      *string `json:"reviewer_id,omitempty"`
+	ActorID         *string `json:"actor_id,omitempty"`
+	Reason          string  `json:"reason,omitempty"`
+}
+
+// userEventPayload - описание активации и деактивации пользователя.
+type userEventPayload struct {
+	UserID   string  `json:"user_id"`
+	TeamName *string `json:"team_name,omitempty"`
+	ActorID  *string `json:"actor_id,omitempty"`
+}
+
+// teamMembershipPayload - описание изменения состава команды.
+type teamMembershipPayload struct {
+	TeamName string   `json:"team_name"`
+	Added    []string `json:"added"`
+	Removed  []string `json:"removed"`
+	ActorID  *string  `json:"actor_id,omitempty"`
+}
+
+func newDomainEvent(eventType domain.DomainEventType, payload any) (domain.DomainEvent, error) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return domain.DomainEvent{}, err
+	}
+	return domain.DomainEvent{Type: eventType, Payload: raw}, nil
+}
+
+// assignmentDomainEvents переводит события истории назначений в доменные: замена ревьювера
+// становится снятием прежнего и назначением нового.
+func assignmentDomainEvents(events []domain.AssignmentEvent) ([]domain.DomainEvent, error) {
+	result := make([]domain.DomainEvent, 0, len(events))
+	add := func(eventType domain.DomainEventType, event domain.AssignmentEvent, reviewerID *string) error {
+		domainEvent, err := newDomainEvent(eventType, pullRequestEventPayload{
+			PullRequestID: event.PullRequestID,
+			ReviewerID:    reviewerID,
+			ActorID:       event.ActorID,
+			Reason:        event.Reason,
+		})
+		if err != nil {
+			return err
+		}
+		result = append(result, domainEvent)
+		return nil
+	}
+
+	for _, event := range events {
+		var err error
+		switch event.Type {
+		case domain.AssignmentEventAssigned:
+			err = add(domain.EventReviewerAssigned, event, event.ReviewerID)
+		case domain.AssignmentEventUnassigned:
+			err = add(domain.EventReviewerUnassigned, event, event.ReviewerID)
+		case domain.AssignmentEventReassigned:
+			if err = add(domain.EventReviewerUnassigned, event, event.PreviousReviewerID); err == nil {
+				err = add(domain.EventReviewerAssigned, event, event.ReviewerID)
+			}
+		case domain.AssignmentEventMerged:
+			err = add(domain.EventPRMerged, event, nil)
+		case domain.AssignmentEventClosed:
+			err = add(domain.EventPRClosed, event, nil)
+		case domain.AssignmentEventReopened:
+			err = add(domain.EventPRReopened, event, nil)
+		case domain.AssignmentEventReviewed:
+			err = add(domain.EventReviewSubmitted, event, event.ReviewerID)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// userActivityEvent описывает смену активности пользователя userID из команды teamName на isActive.
+func userActivityEvent(ctx context.Context, userID string, teamName *string, isActive bool) (domain.DomainEvent, error) {
+	eventType := domain.EventUserDeactivated
+	if isActive {
+		eventType = domain.EventUserActivated
+	}
+	return newDomainEvent(eventType, userEventPayload{
+		UserID:   userID,
+		TeamName: teamName,
+		ActorID:  actorFromContext(ctx),
+	})
+}
+
+// membershipEvent описывает изменение состава команды teamName с previous на current;
+// ok = false, если состав не изменился.
+func membershipEvent(
+	ctx context.Context,
+	teamName string,
+	previous, current []domain.Member,
+) (event domain.DomainEvent, ok bool, err error) {
+	payload := teamMembershipPayload{
+		TeamName: teamName,
+		Added:    []string{},
+		Removed:  []string{},
+		ActorID:  actorFromContext(ctx),
+	}
+	hasMember := func(members []domain.Member, userID string) bool {
+		return slices.ContainsFunc(members, func(member domain.Member) bool {
+			return member.UserID == userID
+		})
+	}
+	for _, member := range current {
+		if !hasMember(previous, member.UserID) {
+			payload.Added = append(payload.Added, member.UserID)
+		}
+	}
+	for _, member := range previous {
+		if !hasMember(current, member.UserID) {
+			payload.Removed = append(payload.Removed, member.UserID)
+		}
+	}
+	if len(payload.Added) == 0 && len(payload.Removed) == 0 {
+		return domain.DomainEvent{}, false, nil
+	}
+
+	event, err = newDomainEvent(domain.EventTeamMembershipChanged, payload)
+	return event, err == nil, err
+}
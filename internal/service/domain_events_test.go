@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/service"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeEventRepo struct {
+	mu     sync.Mutex
+	events []domain.DomainEvent
+}
+
+func (repo *fakeEventRepo) Append(_ context.Context, events []domain.DomainEvent) error {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, event := range events {
+		event.ID = int64(len(repo.events) + 1)
+		event.Cursor = domain.EventCursor{TxID: 1, ID: event.ID}
+		repo.events = append(repo.events, event)
+	}
+	return nil
+}
+
+func (repo *fakeEventRepo) ListAfter(_ context.Context, after domain.EventCursor, limit int) ([]domain.DomainEvent, error) {
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	result := make([]domain.DomainEvent, 0)
+	for _, event := range repo.events {
+		if event.ID > after.ID && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+func (repo *fakeEventRepo) Lag(context.Context, domain.EventCursor) (domain.EventLag, error) {
+	return domain.EventLag{}, nil
+}
+
+type fakeNotifier struct {
+	changed chan struct{}
+}
+
+func (notifier *fakeNotifier) Changed() <-chan struct{} {
+	return notifier.changed
+}
+
+func TestEventServicePoll(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns existing events without waiting", func(t *testing.T) {
+		repo := &fakeEventRepo{}
+		_ = repo.Append(ctx, []domain.DomainEvent{{Type: domain.EventPRCreated}, {Type: domain.EventReviewerAssigned}})
+		events := service.NewEventService(repo, &fakeNotifier{changed: make(chan struct{})})
+
+		got, err := events.Poll(ctx, domain.EventCursor{TxID: 1, ID: 1}, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0].Type != domain.EventReviewerAssigned {
+			t.Fatalf("expected REVIEWER_ASSIGNED after cursor, got %+v", got)
+		}
+	})
+
+	t.Run("wakes up on notification", func(t *testing.T) {
+		repo := &fakeEventRepo{}
+		notifier := &fakeNotifier{changed: make(chan struct{})}
+		events := service.NewEventService(repo, notifier)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = repo.Append(ctx, []domain.DomainEvent{{Type: domain.EventPRMerged}})
+			close(notifier.changed)
+		}()
+
+		got, err := events.Poll(ctx, domain.EventCursor{}, 10, time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 1 || got[0].Type != domain.EventPRMerged {
+			t.Fatalf("expected PR_MERGED, got %+v", got)
+		}
+	})
+
+	t.Run("returns empty list after wait", func(t *testing.T) {
+		events := service.NewEventService(&fakeEventRepo{}, &fakeNotifier{changed: make(chan struct{})})
+
+		got, err := events.Poll(ctx, domain.EventCursor{}, 10, 10*time.Millisecond)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(got) != 0 {
+			t.Fatalf("expected no events, got %+v", got)
+		}
+	})
+}
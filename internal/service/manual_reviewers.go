@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+)
+
+// AddReviewer вручную назначает reviewerID ревьювером открытого PR сверх выбранных автоматически.
+// Ревьювер должен быть активен, не в отпуске, не может быть автором и не должен быть исключён правилом
+// NEVER_REVIEWS команды автора. Ревьювер может быть из любой команды (например, эксперт из соседней),
+// лимит открытых ревью не проверяется.
+// Если expectedVersion задан и не совпадает с текущей версией PR, возвращается domain.ErrVersionConflict.
+func (service *PullRequestService) AddReviewer(
+	ctx context.Context,
+	prID, reviewerID string,
+	expectedVersion *int64,
+) (*domain.PullRequestAssignment, error) {
+	var prAssignments *domain.PullRequestAssignment
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := service.lockOpenPR(ctx, prID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		reviewer, err := service.userRepo.GetByID(ctx, reviewerID)
+		if err != nil {
+			return err
+		}
+		if reviewerID == pr.AuthorID {
+			return domain.ErrReviewerIsAuthor
+		}
+		if !reviewer.IsActive {
+			return domain.ErrReviewerInactive
+		}
+		if slices.Contains(pr.ReviewerIDs(), reviewerID) {
+			return domain.ErrAlreadyAssigned
+		}
+
+		author, err := service.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		if author.TeamName == nil {
+			return domain.ErrTeamNotFound
+		}
+		if reviewer.TeamName == nil {
+			return fmt.Errorf("%w: %s is not in any team", domain.ErrReviewerUnavailable, reviewerID)
+		}
+
+		member, excluded, err := service.lookupCandidate(ctx, *reviewer.TeamName, *author.TeamName, pr.AuthorID, reviewerID)
+		if err != nil {
+			return err
+		}
+		if excluded {
+			return domain.ErrReviewerExcluded
+		}
+		if member == nil {
+			return domain.ErrReviewerUnavailable
+		}
+
+		if err := service.repo.AddReviewer(ctx, prID, reviewerID); err != nil {
+			return err
+		}
+
+		event := service.newEvent(ctx, prID, domain.AssignmentEventAssigned, reviewerID, nil, domain.ReasonReviewerAdded)
+		prAssignments, err = service.finishManualChange(ctx, pr, append(pr.ReviewerIDs(), reviewerID), event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prAssignments, nil
+}
+
+// RemoveReviewer снимает reviewerID с открытого PR без замены; PR может остаться с недостаточным
+// числом ревьюверов (insufficient_reviewers).
+// Если expectedVersion задан и не совпадает с текущей версией PR, возвращается domain.ErrVersionConflict.
+func (service *PullRequestService) RemoveReviewer(
+	ctx context.Context,
+	prID, reviewerID string,
+	expectedVersion *int64,
+) (*domain.PullRequestAssignment, error) {
+	var prAssignments *domain.PullRequestAssignment
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		pr, err := service.lockOpenPR(ctx, prID, expectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if err := service.repo.RemoveReviewer(ctx, prID, reviewerID); err != nil {
+			return err
+		}
+
+		reviewers := slices.DeleteFunc(pr.ReviewerIDs(), func(userID string) bool { return userID == reviewerID })
+		event := service.newEvent(ctx, prID, domain.AssignmentEventUnassigned, reviewerID, nil, domain.ReasonReviewerRemoved)
+		prAssignments, err = service.finishManualChange(ctx, pr, reviewers, event)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prAssignments, nil
+}
+
+// lockOpenPR блокирует строку PR, сверяет версию и проверяет, что PR открыт.
+func (service *PullRequestService) lockOpenPR(
+	ctx context.Context,
+	prID string,
+	expectedVersion *int64,
+) (*domain.PullRequestDetails, error) {
+	version, err := service.repo.LockPR(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+	if expectedVersion != nil && *expectedVersion != version {
+		return nil, domain.ErrVersionConflict
+	}
+
+	pr, err := service.repo.GetByID(ctx, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pr.Status {
+	case domain.PRMergeStatus:
+		return nil, domain.ErrPRMerged
+	case domain.PRClosedStatus:
+		return nil, domain.ErrPRClosed
+	}
+
+	return pr, nil
+}
+
+// finishManualChange записывает событие ручного изменения ревьюверов, увеличивает версию PR
+// и собирает его состояние с ревьюверами reviewers.
+func (service *PullRequestService) finishManualChange(
+	ctx context.Context,
+	pr *domain.PullRequestDetails,
+	reviewers []string,
+	event domain.AssignmentEvent,
+) (*domain.PullRequestAssignment, error) {
+	if err := service.recordEvents(ctx, []domain.AssignmentEvent{event}); err != nil {
+		return nil, err
+	}
+
+	version, err := service.repo.BumpVersion(ctx, pr.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.PullRequestAssignment{
+		PullRequest: domain.PullRequest{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+			Version:         version,
+		},
+		AssignedReviewers:     reviewers,
+		RequiredReviewers:     pr.RequiredReviewers,
+		InsufficientReviewers: len(reviewers) < pr.RequiredReviewers,
+	}, nil
+}
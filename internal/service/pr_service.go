@@ -2,44 +2,192 @@ package service
 
 import (
 	"context"
+	"fmt"
 	"pr-reviewer-assigment-service/internal/domain"
 	"slices"
-	"sort"
+	"time"
 )
 
 type PullRequestRepository interface {
-	GetReviewPRs(ctx context.Context, userID string) ([]domain.PullRequest, error)
-	Create(ctx context.Context, prID, prName, authorID string) error
+	GetReviewPRs(ctx context.Context, userID string) ([]domain.AssignedReview, error)
+	ListPendingReviewsByTeam(ctx context.Context, teamName string) ([]domain.AssignedReview, error)
+	ListAutoReassignableReviews(ctx context.Context) ([]domain.AssignedReview, error)
+	Create(ctx context.Context, prID, prName, authorID string, requiredReviewers int, tags []string) error
 	AssignReviewers(ctx context.Context, prID string, reviewers []string) error
 	Merge(ctx context.Context, prID string) (*domain.PullRequestAssignment, error)
-	IsExists(ctx context.Context, prID string) (bool, error)
-	GetPRReviewers(ctx context.Context, prID string) ([]string, error)
-	GetPRAuthors(ctx context.Context, prID string) (string, error)
-	GetPRNameByID(ctx context.Context, prID string) (string, error)
-	DeleteAssignedUser(ctx context.Context, prID string) error
+	LockPR(ctx context.Context, prID string) (int64, error)
+	BumpVersion(ctx context.Context, prID string) (int64, error)
+	Close(ctx context.Context, prID string) error
+	Reopen(ctx context.Context, prID string) error
+	GetByID(ctx context.Context, prID string) (*domain.PullRequestDetails, error)
+	List(ctx context.Context, query domain.PullRequestListQuery) (*domain.PullRequestPage, error)
+	ListOpenReviews(ctx context.Context, userIDs []string) ([]domain.ReviewAssignment, error)
+	AddReviewer(ctx context.Context, prID, userID string) error
+	SetReviewState(ctx context.Context, prID, userID string, state domain.ReviewState) error
+	RemoveReviewer(ctx context.Context, prID, userID string) error
 }
 
-const PRReviewers int = 2
+// AssignmentEventRepository хранит append-only историю назначений ревьюверов.
+type AssignmentEventRepository interface {
+	Append(ctx context.Context, events []domain.AssignmentEvent) error
+	ListByPR(ctx context.Context, prID string) ([]domain.AssignmentEvent, error)
+}
+
+// Transactor выполняет fn в одной транзакции: все вызовы репозиториев с переданным ctx
+// либо применяются вместе, либо откатываются.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
 
 type PullRequestService struct {
-	repo     PullRequestRepository
-	userRepo UserRepository
-	teamRepo TeamRepository
+	repo          PullRequestRepository
+	userRepo      UserRepository
+	teamRepo      TeamRepository
+	codeOwnerRepo CodeOwnerRepository
+	ruleRepo      ReviewerRuleRepository
+	eventRepo     AssignmentEventRepository
+	domainEvents  DomainEventRepository
+	tx            Transactor
+}
+
+func NewPullRequestService(
+	repo PullRequestRepository,
+	userRepo UserRepository,
+	teamRepo TeamRepository,
+	codeOwnerRepo CodeOwnerRepository,
+	ruleRepo ReviewerRuleRepository,
+	eventRepo AssignmentEventRepository,
+	domainEvents DomainEventRepository,
+	tx Transactor,
+) *PullRequestService {
+	return &PullRequestService{
+		repo:          repo,
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		codeOwnerRepo: codeOwnerRepo,
+		ruleRepo:      ruleRepo,
+		eventRepo:     eventRepo,
+		domainEvents:  domainEvents,
+		tx:            tx,
+	}
 }
 
-func NewPullRequestService(repo PullRequestRepository, userRepo UserRepository, teamRepo TeamRepository) *PullRequestService {
-	return &PullRequestService{repo: repo, userRepo: userRepo, teamRepo: teamRepo}
+// GetReview возвращает PR, где пользователь назначен ревьювером, с отметкой о нарушении SLA его команды.
+func (service *PullRequestService) GetReview(ctx context.Context, id string) ([]domain.AssignedReview, error) {
+	reviews, err := service.repo.GetReviewPRs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range reviews {
+		reviews[i].ApplySLA(now)
+	}
+	return reviews, nil
 }
 
-func (service *PullRequestService) GetReview(ctx context.Context, id string) ([]domain.PullRequest, error) {
-	prs, err := service.repo.GetReviewPRs(ctx, id)
+// Create создаёт PR и назначает ревьюверов из команды автора (см. selectNewReviewers).
+// reviewersCount переопределяет required_reviewers команды; nil - взять значение команды.
+func (service *PullRequestService) Create(
+	ctx context.Context,
+	prID, prName, authorID string,
+	reviewersCount *int,
+	changedFiles []string,
+	tags []string,
+) (*domain.PullRequestAssignment, error) {
+	target, err := service.newPRTarget(ctx, authorID, reviewersCount, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	var selection *reviewerSelection
+	err = service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		// блокировка команд сериализует назначения, чтобы нагрузка кандидатов не устаревала
+		ownerTeams, err := service.codeOwnerTeams(ctx, target.teamName, changedFiles)
+		if err != nil {
+			return err
+		}
+		if err := service.lockTeams(ctx, target.teamName, target.settings, ownerTeams...); err != nil {
+			return err
+		}
+
+		if err := service.repo.Create(ctx, prID, prName, authorID, target.required, target.tags); err != nil {
+			return err
+		}
+
+		selection, err = service.selectNewReviewers(ctx, service.teamRepo.GetTeamsMembersByTeamName, target, authorID, changedFiles)
+		if err != nil {
+			return err
+		}
+
+		if err := service.repo.AssignReviewers(ctx, prID, selection.reviewers); err != nil {
+			return err
+		}
+
+		created, err := newDomainEvent(domain.EventPRCreated, pullRequestEventPayload{
+			PullRequestID:   prID,
+			PullRequestName: prName,
+			AuthorID:        authorID,
+			ActorID:         actorFromContext(ctx),
+		})
+		if err != nil {
+			return err
+		}
+		if err := service.domainEvents.Append(ctx, []domain.DomainEvent{created}); err != nil {
+			return err
+		}
+
+		events := make([]domain.AssignmentEvent, 0, len(selection.reviewers))
+		for _, reviewerID := range selection.reviewers {
+			events = append(events, service.newEvent(ctx, prID, domain.AssignmentEventAssigned, reviewerID, nil, domain.ReasonPRCreated))
+		}
+		return service.recordEvents(ctx, events)
+	})
 	if err != nil {
 		return nil, err
 	}
-	return prs, nil
+
+	var prAssignments domain.PullRequestAssignment
+	prAssignments.AssignedReviewers = selection.reviewers
+	prAssignments.FallbackReviewers = selection.fallback
+	prAssignments.CodeOwnerReviewers = selection.codeOwners
+	prAssignments.RuleEffects = selection.effects
+	prAssignments.RequiredReviewers = target.required
+	prAssignments.InsufficientReviewers = len(selection.reviewers) < target.required
+
+	prAssignments.PullRequestID = prID
+	prAssignments.PullRequestName = prName
+	prAssignments.AuthorID = authorID
+	prAssignments.Status = domain.PROpenStatus
+	prAssignments.Version = 1
+
+	return &prAssignments, nil
+}
+
+// newPRTarget - команда автора нового PR, её настройки, требуемое число ревьюверов и нормализованные теги.
+type newPRTarget struct {
+	teamName string
+	settings *domain.TeamSettings
+	required int
+	tags     []string
 }
 
-func (service *PullRequestService) Create(ctx context.Context, prID, prName, authorID string) (*domain.PullRequestAssignment, error) {
+// newPRTarget проверяет параметры нового PR и находит команду автора.
+func (service *PullRequestService) newPRTarget(
+	ctx context.Context,
+	authorID string,
+	reviewersCount *int,
+	tags []string,
+) (*newPRTarget, error) {
+	if reviewersCount != nil && (*reviewersCount < 1 || *reviewersCount > domain.MaxReviewers) {
+		return nil, domain.ErrInvalidReviewersCount
+	}
+
+	tags, err := domain.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := service.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, err
@@ -48,53 +196,223 @@ func (service *PullRequestService) Create(ctx context.Context, prID, prName, aut
 		return nil, domain.ErrTeamNotFound
 	}
 
-	err = service.repo.Create(ctx, prID, prName, authorID)
+	settings, err := service.teamRepo.GetTeamSettings(ctx, *user.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	required := settings.RequiredReviewers
+	if reviewersCount != nil {
+		required = *reviewersCount
+	}
+
+	return &newPRTarget{
+		teamName: *user.TeamName,
+		settings: settings,
+		required: required,
+		tags:     tags,
+	}, nil
+}
+
+// selectNewReviewers выбирает ревьюверов нового PR автора authorID, ничего не записывая.
+// Сначала применяются правила команды для автора: исключения NEVER_REVIEWS и обязательные REQUIRES_TAG.
+// Владельцы изменённых файлов changedFiles (правила CODEOWNERS команды автора) выбираются первыми,
+// даже если их больше required_reviewers, за ними - ревьюверы по REQUIRES_TAG; оставшиеся места
+// заполняются по стратегии команды, в первую очередь участниками с хотя бы одним из тегов PR.
+func (service *PullRequestService) selectNewReviewers(
+	ctx context.Context,
+	members teamMembersFunc,
+	target *newPRTarget,
+	authorID string,
+	changedFiles []string,
+) (*reviewerSelection, error) {
+	criteria := selectionCriteria{tags: target.tags, exclude: map[string]struct{}{authorID: {}}}
+	requiredRules, effects, err := service.applyExclusionRules(ctx, target.teamName, authorID, criteria.exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := service.pickCodeOwners(ctx, members, target.teamName, target.settings, changedFiles, criteria.exclude)
+	if err != nil {
+		return nil, err
+	}
+	selection := &reviewerSelection{codeOwners: owners, effects: effects}
+	for _, owner := range owners {
+		selection.reviewers = append(selection.reviewers, owner.UserID)
+	}
+
+	byRules, err := service.pickRequiredReviewers(ctx, members, target.teamName, target.settings, requiredRules, selection.reviewers, criteria)
 	if err != nil {
 		return nil, err
 	}
+	selection.merge(byRules)
 
-	assignments, err := service.teamRepo.GetTeamsMembersByTeamName(ctx, *user.TeamName)
+	balanced, err := service.pickReviewers(ctx, members, target.teamName, target.settings, criteria, target.required-len(selection.reviewers))
 	if err != nil {
 		return nil, err
 	}
+	selection.merge(balanced)
 
-	sort.Slice(assignments, func(i, j int) bool {
-		var left, right int64
-		if assignments[i].PRReviews != nil {
-			left = *assignments[i].PRReviews
+	return selection, nil
+}
+
+// Merge идемпотентно переводит PR в MERGED; событие MERGED пишется только при первом merge.
+// Если команда автора требует одобрений (required_approvals), открытый PR без них не мержится:
+// возвращается domain.ErrNotApproved.
+func (service *PullRequestService) Merge(ctx context.Context, prID string) (*domain.PullRequestAssignment, error) {
+	var prAssignments *domain.PullRequestAssignment
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		version, err := service.repo.LockPR(ctx, prID)
+		if err != nil {
+			return err
 		}
-		if assignments[j].PRReviews != nil {
-			right = *assignments[j].PRReviews
+
+		if err := service.checkApprovals(ctx, prID); err != nil {
+			return err
 		}
-		return left < right
-	})
 
-	var prAssignments domain.PullRequestAssignment
-	for _, assignment := range assignments {
-		if len(prAssignments.AssignedReviewers) == PRReviewers {
-			break
+		prAssignments, err = service.repo.Merge(ctx, prID)
+		if err != nil {
+			return err
 		}
 
-		if assignment.UserID != authorID {
-			prAssignments.AssignedReviewers = append(prAssignments.AssignedReviewers, assignment.UserID)
+		// версия меняется только если PR действительно перешёл в MERGED
+		if prAssignments.Version == version {
+			return nil
 		}
+		return service.recordEvents(ctx, []domain.AssignmentEvent{
+			service.newEvent(ctx, prID, domain.AssignmentEventMerged, "", nil, domain.ReasonPRMerged),
+		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	err = service.repo.AssignReviewers(ctx, prID, prAssignments.AssignedReviewers)
+	return prAssignments, nil
+}
+
+// Close закрывает открытый PR без merge: ревьюверы остаются в PR, но он перестаёт учитываться
+// в нагрузке и статистике. Повторное закрытие ничего не меняет, смерженный PR закрыть нельзя.
+func (service *PullRequestService) Close(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	return service.changeStatus(ctx, prID, domain.PRClosedStatus)
+}
+
+// Reopen возвращает закрытый PR в OPEN. Для открытого PR ничего не меняет, смерженный переоткрыть нельзя.
+func (service *PullRequestService) Reopen(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	return service.changeStatus(ctx, prID, domain.PROpenStatus)
+}
+
+// changeStatus идемпотентно переводит PR между OPEN и CLOSED (в статус to) и пишет событие в историю.
+func (service *PullRequestService) changeStatus(
+	ctx context.Context,
+	prID string,
+	to domain.PRStatus,
+) (*domain.PullRequestDetails, error) {
+	var pr *domain.PullRequestDetails
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := service.repo.LockPR(ctx, prID); err != nil {
+			return err
+		}
+
+		var err error
+		pr, err = service.repo.GetByID(ctx, prID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Status == to {
+			return nil
+		}
+		if pr.Status == domain.PRMergeStatus {
+			return domain.ErrPRMerged
+		}
+
+		var event domain.AssignmentEvent
+		if to == domain.PRClosedStatus {
+			err = service.repo.Close(ctx, prID)
+			event = service.newEvent(ctx, prID, domain.AssignmentEventClosed, "", nil, domain.ReasonPRClosed)
+		} else {
+			err = service.repo.Reopen(ctx, prID)
+			event = service.newEvent(ctx, prID, domain.AssignmentEventReopened, "", nil, domain.ReasonPRReopened)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := service.recordEvents(ctx, []domain.AssignmentEvent{event}); err != nil {
+			return err
+		}
+
+		pr, err = service.repo.GetByID(ctx, prID)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	prAssignments.PullRequestID = prID
-	prAssignments.PullRequestName = prName
-	prAssignments.AuthorID = authorID
-	prAssignments.Status = domain.PROpenStatus
+	return pr, nil
+}
 
-	return &prAssignments, nil
+// Get возвращает PR с ревьюверами и временными метками.
+func (service *PullRequestService) Get(ctx context.Context, prID string) (*domain.PullRequestDetails, error) {
+	return service.repo.GetByID(ctx, prID)
 }
 
-func (service *PullRequestService) Merge(ctx context.Context, prID string) (*domain.PullRequestAssignment, error) {
-	prAssignments, err := service.repo.Merge(ctx, prID)
+// List возвращает страницу PR по фильтрам. Пустая сортировка - created_at,
+// лимит приводится к диапазону [1, domain.MaxPRListLimit].
+func (service *PullRequestService) List(ctx context.Context, query domain.PullRequestListQuery) (*domain.PullRequestPage, error) {
+	if query.Sort == "" {
+		query.Sort = domain.PRSortCreatedAt
+	}
+	if !query.Sort.IsValid() {
+		return nil, domain.ErrInvalidListQuery
+	}
+	if query.Status != "" && !query.Status.IsValid() {
+		return nil, domain.ErrInvalidListQuery
+	}
+	if isAfter(query.CreatedFrom, query.CreatedTo) || isAfter(query.MergedFrom, query.MergedTo) {
+		return nil, domain.ErrInvalidListQuery
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = domain.DefaultPRListLimit
+	}
+	query.Limit = min(query.Limit, domain.MaxPRListLimit)
+
+	// курсор действителен только для той сортировки, в которой был выдан
+	if cursor := query.Cursor; cursor != nil && (cursor.Sort != query.Sort || cursor.Desc != query.Desc) {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return service.repo.List(ctx, query)
+}
+
+// isAfter сообщает, что заданы обе границы диапазона и from позже to.
+func isAfter(from, to *time.Time) bool {
+	return from != nil && to != nil && from.After(*to)
+}
+
+// History возвращает историю назначений ревьюверов PR в хронологическом порядке.
+func (service *PullRequestService) History(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	return service.eventRepo.ListByPR(ctx, prID)
+}
+
+// Reassign заменяет ревьювера replacedUserID другим участником команды автора.
+// Если newReviewerID задан, замена не подбирается, а передаётся этому пользователю (см. handOff).
+// Все чтения и изменения выполняются в одной транзакции под блокировкой PR и команды.
+// Если expectedVersion задан и не совпадает с текущей версией PR, возвращается domain.ErrVersionConflict.
+func (service *PullRequestService) Reassign(
+	ctx context.Context,
+	prID, replacedUserID string,
+	newReviewerID *string,
+	expectedVersion *int64,
+) (*domain.PullRequestAssignment, error) {
+	var prAssignments *domain.PullRequestAssignment
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		prAssignments, err = service.reassign(ctx, prID, replacedUserID, newReviewerID, expectedVersion)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -102,44 +420,154 @@ func (service *PullRequestService) Merge(ctx context.Context, prID string) (*dom
 	return prAssignments, nil
 }
 
-func (service *PullRequestService) Reassign(ctx context.Context, prID, replacedUserID string) (*domain.PullRequestAssignment, error) {
-	isPrExists, err := service.repo.IsExists(ctx, prID)
+func (service *PullRequestService) reassign(
+	ctx context.Context,
+	prID, replacedUserID string,
+	newReviewerID *string,
+	expectedVersion *int64,
+) (*domain.PullRequestAssignment, error) {
+	version, err := service.repo.LockPR(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
-	if !isPrExists {
-		return nil, domain.ErrPRNotFound
+	if expectedVersion != nil && *expectedVersion != version {
+		return nil, domain.ErrVersionConflict
 	}
 
-	user, err := service.userRepo.GetByID(ctx, replacedUserID)
-	if err != nil {
+	if _, err := service.userRepo.GetByID(ctx, replacedUserID); err != nil {
 		return nil, err
 	}
-	prs, err := service.repo.GetReviewPRs(ctx, user.ID)
+
+	pr, err := service.repo.GetByID(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
-	if hasPR := slices.ContainsFunc(prs, func(pr domain.PullRequest) bool {
-		return pr.PullRequestID == prID
-	}); !hasPR {
+
+	if !slices.Contains(pr.ReviewerIDs(), replacedUserID) {
 		return nil, domain.ErrIsNotAssigned
 	}
-
-	if isPrMerges := slices.ContainsFunc(prs, func(pr domain.PullRequest) bool {
-		return pr.PullRequestID == prID && pr.Status == domain.PRMergeStatus
-	}); isPrMerges {
+	if pr.Status == domain.PRMergeStatus {
 		return nil, domain.ErrPRMerged
 	}
+	if pr.Status == domain.PRClosedStatus {
+		return nil, domain.ErrPRClosed
+	}
+
+	if newReviewerID != nil {
+		return service.handOff(ctx, pr, replacedUserID, *newReviewerID)
+	}
+
+	return service.replaceReviewer(ctx, pr, replacedUserID, nil, domain.ReasonReassignRequested, service.teamRepo.GetTeamsMembersByTeamName)
+}
+
+// handOff передаёт ревью replacedUserID пользователю newReviewerID. Новый ревьювер должен быть активным
+// участником команды автора или её резервной команды, не автором и не ревьювером этого PR, не быть в отпуске,
+// не исчерпать max_open_reviews и не быть исключён правилом NEVER_REVIEWS, иначе возвращается
+// domain.ErrIsNoCandidates. Недостающие до required_reviewers не добираются.
+func (service *PullRequestService) handOff(
+	ctx context.Context,
+	pr *domain.PullRequestDetails,
+	replacedUserID, newReviewerID string,
+) (*domain.PullRequestAssignment, error) {
+	prID := pr.PullRequestID
+
+	author, err := service.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author.TeamName == nil {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	settings, err := service.teamRepo.GetTeamSettings(ctx, *author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewer, err := service.userRepo.GetByID(ctx, newReviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case newReviewerID == pr.AuthorID:
+		return nil, fmt.Errorf("%w: %s is the author", domain.ErrIsNoCandidates, newReviewerID)
+	case !reviewer.IsActive:
+		return nil, fmt.Errorf("%w: %s is not active", domain.ErrIsNoCandidates, newReviewerID)
+	case slices.Contains(pr.ReviewerIDs(), newReviewerID):
+		return nil, fmt.Errorf("%w: %s is already assigned", domain.ErrIsNoCandidates, newReviewerID)
+	case reviewer.TeamName == nil ||
+		(*reviewer.TeamName != *author.TeamName && !slices.Contains(settings.FallbackTeams, *reviewer.TeamName)):
+		return nil, fmt.Errorf("%w: %s is not in the author's team", domain.ErrIsNoCandidates, newReviewerID)
+	}
 
-	reviewers, err := service.repo.GetPRReviewers(ctx, prID)
+	member, excluded, err := service.lookupCandidate(ctx, *reviewer.TeamName, *author.TeamName, pr.AuthorID, newReviewerID)
 	if err != nil {
 		return nil, err
 	}
+	switch {
+	case member == nil:
+		return nil, fmt.Errorf("%w: %s is unavailable", domain.ErrIsNoCandidates, newReviewerID)
+	case excluded:
+		return nil, fmt.Errorf("%w: %s is excluded by a reviewer rule", domain.ErrIsNoCandidates, newReviewerID)
+	case member.AtCapacity():
+		return nil, fmt.Errorf("%w: %s has reached max_open_reviews", domain.ErrIsNoCandidates, newReviewerID)
+	}
+
+	if err := service.repo.RemoveReviewer(ctx, prID, replacedUserID); err != nil {
+		return nil, err
+	}
+	if err := service.repo.AddReviewer(ctx, prID, newReviewerID); err != nil {
+		return nil, err
+	}
+
+	event := service.newEvent(ctx, prID, domain.AssignmentEventReassigned, newReviewerID, &replacedUserID, domain.ReasonReassignRequested)
+	if err := service.recordEvents(ctx, []domain.AssignmentEvent{event}); err != nil {
+		return nil, err
+	}
 
-	authorID, err := service.repo.GetPRAuthors(ctx, prID)
+	version, err := service.repo.BumpVersion(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
+
+	reviewers := slices.DeleteFunc(pr.ReviewerIDs(), func(userID string) bool { return userID == replacedUserID })
+	reviewers = append(reviewers, newReviewerID)
+
+	prAssignments := &domain.PullRequestAssignment{
+		PullRequest: domain.PullRequest{
+			PullRequestID:   prID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          domain.PROpenStatus,
+			Version:         version,
+		},
+		AssignedReviewers:     reviewers,
+		ReplacedBy:            &replacedUserID,
+		RequiredReviewers:     pr.RequiredReviewers,
+		InsufficientReviewers: len(reviewers) < pr.RequiredReviewers,
+	}
+	if *reviewer.TeamName != *author.TeamName {
+		prAssignments.FallbackReviewers = []domain.FallbackReviewer{{UserID: newReviewerID, TeamName: *reviewer.TeamName}}
+	}
+
+	return prAssignments, nil
+}
+
+// replaceReviewer заменяет replacedUserID в открытом PR участником команды автора (или резервной команды)
+// и добирает недостающих до required_reviewers. Строка PR должна быть заблокирована (LockPR).
+// Пользователи из unavailable не назначаются; members отдаёт кандидатов команды.
+// Если заменить некем, возвращается domain.ErrIsNoCandidates и PR не меняется.
+func (service *PullRequestService) replaceReviewer(
+	ctx context.Context,
+	pr *domain.PullRequestDetails,
+	replacedUserID string,
+	unavailable []string,
+	reason string,
+	members teamMembersFunc,
+) (*domain.PullRequestAssignment, error) {
+	prID := pr.PullRequestID
+	authorID := pr.AuthorID
 	author, err := service.userRepo.GetByID(ctx, authorID)
 	if err != nil {
 		return nil, err
@@ -149,74 +577,304 @@ func (service *PullRequestService) Reassign(ctx context.Context, prID, replacedU
 		return nil, domain.ErrTeamNotFound
 	}
 
-	assignments, err := service.teamRepo.GetTeamsMembersByTeamName(ctx, *author.TeamName)
+	settings, err := service.teamRepo.GetTeamSettings(ctx, *author.TeamName)
 	if err != nil {
 		return nil, err
 	}
 
-	sort.Slice(assignments, func(i, j int) bool {
-		var left, right int64
-		if assignments[i].PRReviews != nil {
-			left = *assignments[i].PRReviews
-		}
-		if assignments[j].PRReviews != nil {
-			right = *assignments[j].PRReviews
-		}
-		return left < right
-	})
-
-	var prAssignments domain.PullRequestAssignment
-	existing := make(map[string]struct{})
-
-	for _, reviewer := range reviewers {
-		if reviewer != replacedUserID {
-			prAssignments.AssignedReviewers = append(prAssignments.AssignedReviewers, reviewer)
-			existing[reviewer] = struct{}{}
-		}
+	if err := service.lockTeams(ctx, *author.TeamName, settings); err != nil {
+		return nil, err
+	}
 
+	remaining, selection, err := service.planReplacement(ctx, pr, *author.TeamName, settings, replacedUserID, unavailable, members)
+	if err != nil {
+		return nil, err
 	}
-	countReviews := len(prAssignments.AssignedReviewers)
 
-	for _, assignment := range assignments {
-		if len(prAssignments.AssignedReviewers) == PRReviewers {
-			break
-		}
+	candidates := selection.reviewers
+	required := pr.RequiredReviewers
 
-		if assignment.UserID == replacedUserID || assignment.UserID == authorID {
-			continue
-		}
-		if _, used := existing[assignment.UserID]; used {
-			continue
-		}
+	var prAssignments domain.PullRequestAssignment
+	prAssignments.AssignedReviewers = append(remaining, candidates...)
+	prAssignments.FallbackReviewers = selection.fallback
+	prAssignments.RuleEffects = selection.effects
 
-		prAssignments.AssignedReviewers = append(prAssignments.AssignedReviewers, assignment.UserID)
-		existing[assignment.UserID] = struct{}{}
+	// первый кандидат занимает место заменяемого, остальные добирают недостающих
+	events := []domain.AssignmentEvent{
+		service.newEvent(ctx, prID, domain.AssignmentEventReassigned, candidates[0], &replacedUserID, reason),
+	}
+	for _, reviewerID := range candidates[1:] {
+		events = append(events, service.newEvent(ctx, prID, domain.AssignmentEventAssigned, reviewerID, nil, reason))
 	}
 
-	if len(prAssignments.AssignedReviewers) == countReviews {
-		return nil, domain.ErrIsNoCandidates
+	// оставшиеся ревьюверы сохраняют время назначения
+	if err := service.repo.RemoveReviewer(ctx, prID, replacedUserID); err != nil {
+		return nil, err
 	}
 
-	err = service.repo.DeleteAssignedUser(ctx, prID)
-	if err != nil {
+	if err := service.repo.AssignReviewers(ctx, prID, candidates); err != nil {
 		return nil, err
 	}
 
-	err = service.repo.AssignReviewers(ctx, prID, prAssignments.AssignedReviewers)
-	if err != nil {
+	if err = service.recordEvents(ctx, events); err != nil {
 		return nil, err
 	}
 
-	prName, err := service.repo.GetPRNameByID(ctx, prID)
+	version, err := service.repo.BumpVersion(ctx, prID)
 	if err != nil {
 		return nil, err
 	}
 
 	prAssignments.PullRequestID = prID
-	prAssignments.PullRequestName = prName
+	prAssignments.PullRequestName = pr.PullRequestName
 	prAssignments.AuthorID = authorID
 	prAssignments.Status = domain.PROpenStatus
 	prAssignments.ReplacedBy = &replacedUserID
+	prAssignments.Version = version
+	prAssignments.RequiredReviewers = required
+	prAssignments.InsufficientReviewers = len(prAssignments.AssignedReviewers) < required
 
 	return &prAssignments, nil
 }
+
+// lockTeams блокирует команду автора teamName, её резервные команды и extra (команды владельцев кода)
+// до конца транзакции: из них выбираются ревьюверы, и параллельное назначение не должно читать их нагрузку.
+func (service *PullRequestService) lockTeams(
+	ctx context.Context,
+	teamName string,
+	settings *domain.TeamSettings,
+	extra ...string,
+) error {
+	teams := append([]string{teamName}, settings.FallbackTeams...)
+	teams = append(teams, extra...)
+	slices.Sort(teams)
+	return service.teamRepo.LockTeams(ctx, slices.Compact(teams))
+}
+
+// planReplacement подбирает замену replacedUserID в PR автора из команды authorTeam (или резервной команды)
+// и кандидатов до required_reviewers, ничего не меняя в БД. Возвращает оставшихся ревьюверов PR и выбор;
+// первый из выбранных занимает место заменяемого. Если заменить некем, возвращается domain.ErrIsNoCandidates.
+func (service *PullRequestService) planReplacement(
+	ctx context.Context,
+	pr *domain.PullRequestDetails,
+	authorTeam string,
+	settings *domain.TeamSettings,
+	replacedUserID string,
+	unavailable []string,
+	members teamMembersFunc,
+) ([]string, *reviewerSelection, error) {
+	exclude := map[string]struct{}{
+		pr.AuthorID:    {},
+		replacedUserID: {},
+	}
+	for _, userID := range unavailable {
+		exclude[userID] = struct{}{}
+	}
+
+	var remaining []string
+	for _, reviewer := range pr.ReviewerIDs() {
+		if reviewer != replacedUserID {
+			remaining = append(remaining, reviewer)
+			exclude[reviewer] = struct{}{}
+		}
+	}
+
+	criteria := selectionCriteria{tags: pr.Tags, exclude: exclude}
+	requiredRules, effects, err := service.applyExclusionRules(ctx, authorTeam, pr.AuthorID, exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	selection, err := service.pickRequiredReviewers(ctx, members, authorTeam, settings, requiredRules, remaining, criteria)
+	if err != nil {
+		return nil, nil, err
+	}
+	selection.effects = append(effects, selection.effects...)
+
+	// заменяемого ревьювера заменяем всегда, даже если остальных уже хватает
+	balanced, err := service.pickReviewers(ctx, members, authorTeam, settings, criteria, max(pr.RequiredReviewers-len(remaining), 1)-len(selection.reviewers))
+	if err != nil {
+		return nil, nil, err
+	}
+	selection.merge(balanced)
+
+	if len(selection.reviewers) == 0 {
+		return nil, nil, domain.ErrIsNoCandidates
+	}
+
+	return remaining, selection, nil
+}
+
+// recordEvents пишет события в историю назначений и соответствующие доменные события в outbox.
+func (service *PullRequestService) recordEvents(ctx context.Context, events []domain.AssignmentEvent) error {
+	if err := service.eventRepo.Append(ctx, events); err != nil {
+		return err
+	}
+
+	domainEvents, err := assignmentDomainEvents(events)
+	if err != nil {
+		return err
+	}
+	return service.domainEvents.Append(ctx, domainEvents)
+}
+
+// newEvent собирает событие истории назначений; исполнитель берётся из ctx (WithActor).
+func (service *PullRequestService) newEvent(
+	ctx context.Context,
+	prID string,
+	eventType domain.AssignmentEventType,
+	reviewerID string,
+	previousReviewerID *string,
+	reason string,
+) domain.AssignmentEvent {
+	event := domain.AssignmentEvent{
+		PullRequestID:      prID,
+		Type:               eventType,
+		PreviousReviewerID: previousReviewerID,
+		ActorID:            actorFromContext(ctx),
+		Reason:             reason,
+	}
+	if reviewerID != "" {
+		event.ReviewerID = &reviewerID
+	}
+	return event
+}
+
+// reviewerSelection - результат выбора ревьюверов.
+type reviewerSelection struct {
+	reviewers []string
+	// fallback - ревьюверы из reviewers, взятые из резервных команд.
+	fallback []domain.FallbackReviewer
+	// codeOwners - ревьюверы из reviewers, назначенные как владельцы изменённых файлов.
+	codeOwners []domain.CodeOwnerReviewer
+	// effects - как правила команды повлияли на выбор.
+	effects []domain.RuleEffect
+}
+
+// merge добавляет к выбору результат следующего шага.
+func (selection *reviewerSelection) merge(next *reviewerSelection) {
+	selection.reviewers = append(selection.reviewers, next.reviewers...)
+	selection.fallback = append(selection.fallback, next.fallback...)
+	selection.codeOwners = append(selection.codeOwners, next.codeOwners...)
+	selection.effects = append(selection.effects, next.effects...)
+}
+
+// selectionCriteria - условия, которым должны отвечать выбираемые ревьюверы.
+type selectionCriteria struct {
+	// tags - теги PR: участники хотя бы с одним из них выбираются первыми.
+	tags []string
+	// exclude - кто не может быть выбран: автор, уже назначенные, исключённые правилами.
+	exclude map[string]struct{}
+	// only, если задан, оставляет только подходящих участников.
+	only func(domain.Member) bool
+}
+
+// teamMembersFunc возвращает активных участников команды с их нагрузкой.
+type teamMembersFunc func(ctx context.Context, teamName string) ([]domain.Member, error)
+
+// pickReviewers выбирает до count ревьюверов среди активных участников команды teamName
+// по стратегии из settings. Если кандидатов не хватило, недостающие добираются из резервных
+// команд (settings.FallbackTeams) в порядке приоритета той же стратегией.
+// В каждой команде сначала выбираются участники с хотя бы одним из тегов PR criteria.tags, затем остальные.
+// Пользователи из criteria.exclude, не прошедшие criteria.only и исчерпавшие лимит открытых ревью не рассматриваются.
+func (service *PullRequestService) pickReviewers(
+	ctx context.Context,
+	members teamMembersFunc,
+	teamName string,
+	settings *domain.TeamSettings,
+	criteria selectionCriteria,
+	count int,
+) (*reviewerSelection, error) {
+	selection := &reviewerSelection{}
+	if count <= 0 {
+		return selection, nil
+	}
+
+	selector, err := NewReviewerSelector(settings.SelectionStrategy)
+	if err != nil {
+		return nil, err
+	}
+
+	teams := append([]string{teamName}, settings.FallbackTeams...)
+	for i, name := range teams {
+		if len(selection.reviewers) >= count {
+			break
+		}
+
+		teamMembers, err := members(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates := make([]domain.Member, 0, len(teamMembers))
+		for _, member := range teamMembers {
+			if isCandidate(member, criteria.exclude) && (criteria.only == nil || criteria.only(member)) {
+				candidates = append(candidates, member)
+			}
+		}
+
+		for _, member := range selectByTags(selector, candidates, criteria.tags, count-len(selection.reviewers)) {
+			selection.reviewers = append(selection.reviewers, member.UserID)
+			if i > 0 {
+				selection.fallback = append(selection.fallback, domain.FallbackReviewer{
+					UserID:   member.UserID,
+					TeamName: name,
+				})
+			}
+		}
+	}
+
+	return selection, nil
+}
+
+// lookupCandidate ищет reviewerID среди кандидатов команды teamName и проверяет правила NEVER_REVIEWS
+// команды автора authorTeam. Возвращает nil, если участник неактивен или в отпуске, и признак исключения правилом.
+func (service *PullRequestService) lookupCandidate(
+	ctx context.Context,
+	teamName, authorTeam, authorID, reviewerID string,
+) (*domain.Member, bool, error) {
+	exclude := make(map[string]struct{})
+	if _, _, err := service.applyExclusionRules(ctx, authorTeam, authorID, exclude); err != nil {
+		return nil, false, err
+	}
+	_, excluded := exclude[reviewerID]
+
+	members, err := service.teamRepo.GetTeamsMembersByTeamName(ctx, teamName)
+	if err != nil {
+		return nil, false, err
+	}
+	for i := range members {
+		if members[i].UserID == reviewerID {
+			return &members[i], excluded, nil
+		}
+	}
+
+	return nil, excluded, nil
+}
+
+// isCandidate сообщает, можно ли назначить участника ревьювером: он активен, не исключён и не исчерпал лимит.
+func isCandidate(member domain.Member, exclude map[string]struct{}) bool {
+	_, excluded := exclude[member.UserID]
+	return !excluded && member.IsActive && !member.AtCapacity()
+}
+
+// selectByTags выбирает до count кандидатов: сначала тех, у кого есть хотя бы один из тегов tags,
+// затем остальных. Внутри каждой группы порядок задаёт стратегия selector, поэтому нагрузка
+// по-прежнему балансируется, но эксперт предпочтительнее менее загруженного неэксперта.
+func selectByTags(selector ReviewerSelector, candidates []domain.Member, tags []string, count int) []domain.Member {
+	if len(tags) == 0 {
+		return selector.Select(candidates, count)
+	}
+
+	var matching, others []domain.Member
+	for _, member := range candidates {
+		if member.HasAnyTag(tags) {
+			matching = append(matching, member)
+		} else {
+			others = append(others, member)
+		}
+	}
+
+	selected := selector.Select(matching, count)
+	return append(selected, selector.Select(others, count-len(selected))...)
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+)
+
+// Preview выполняет тот же выбор ревьюверов, что и Create, но ничего не записывает и не блокирует.
+// Для каждого участника команды автора возвращается нагрузка и причина, по которой он выбран или пропущен.
+// При стратегии WEIGHTED_RANDOM итоговый Create может выбрать других ревьюверов.
+func (service *PullRequestService) Preview(
+	ctx context.Context,
+	authorID string,
+	reviewersCount *int,
+	changedFiles []string,
+	tags []string,
+) (*domain.AssignmentPreview, error) {
+	target, err := service.newPRTarget(ctx, authorID, reviewersCount, tags)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := service.teamRepo.GetTeam(ctx, target.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	// кандидаты команды автора читаются один раз и для выбора, и для объяснения
+	members := newTeamMembersCache(service.teamRepo.GetTeamsMembersByTeamName)
+	selection, err := service.selectNewReviewers(ctx, members.get, target, authorID, changedFiles)
+	if err != nil {
+		return nil, err
+	}
+	eligible, err := members.get(ctx, target.teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AssignmentPreview{
+		AuthorID:              authorID,
+		TeamName:              target.teamName,
+		AssignedReviewers:     selection.reviewers,
+		RequiredReviewers:     target.required,
+		InsufficientReviewers: len(selection.reviewers) < target.required,
+		FallbackReviewers:     selection.fallback,
+		CodeOwnerReviewers:    selection.codeOwners,
+		RuleEffects:           selection.effects,
+		Candidates:            explainCandidates(authorID, team.Members, eligible, selection),
+	}, nil
+}
+
+// explainCandidates объясняет решение по каждому участнику команды: teamMembers - весь состав,
+// eligible - активные и доступные участники с нагрузкой, selection - результат выбора.
+func explainCandidates(
+	authorID string,
+	teamMembers []domain.Member,
+	eligible []domain.Member,
+	selection *reviewerSelection,
+) []domain.ReviewerCandidate {
+	candidates := make([]domain.ReviewerCandidate, 0, len(teamMembers))
+	for _, member := range teamMembers {
+		candidate := domain.ReviewerCandidate{Member: member}
+		i := slices.IndexFunc(eligible, func(m domain.Member) bool { return m.UserID == member.UserID })
+		if i >= 0 {
+			candidate.Member = eligible[i]
+		}
+
+		ownerIdx := slices.IndexFunc(selection.codeOwners, func(o domain.CodeOwnerReviewer) bool { return o.UserID == member.UserID })
+		effect := findEffect(selection.effects, member.UserID)
+
+		switch {
+		case member.UserID == authorID:
+			candidate.Reason = domain.CandidateAuthor
+		case ownerIdx >= 0:
+			candidate.Picked = true
+			candidate.Reason = domain.CandidateCodeOwner
+			candidate.Pattern = selection.codeOwners[ownerIdx].Pattern
+		case effect != nil && effect.Effect == domain.RuleEffectRequired:
+			candidate.Picked = true
+			candidate.Reason = domain.CandidateRequiredByRule
+			candidate.RuleID = &effect.RuleID
+		case slices.Contains(selection.reviewers, member.UserID):
+			candidate.Picked = true
+			candidate.Reason = domain.CandidateSelected
+		case !member.IsActive:
+			candidate.Reason = domain.CandidateInactive
+		case i < 0:
+			candidate.Reason = domain.CandidateUnavailable
+		case effect != nil && effect.Effect == domain.RuleEffectExcluded:
+			candidate.Reason = domain.CandidateExcludedByRule
+			candidate.RuleID = &effect.RuleID
+		case candidate.AtCapacity():
+			candidate.Reason = domain.CandidateAtCapacity
+		default:
+			candidate.Reason = domain.CandidateNotSelected
+		}
+
+		candidates = append(candidates, candidate)
+	}
+	return candidates
+}
+
+// findEffect возвращает влияние правила EXCLUDED или REQUIRED на пользователя userID.
+func findEffect(effects []domain.RuleEffect, userID string) *domain.RuleEffect {
+	for i, effect := range effects {
+		if effect.UserID == userID && (effect.Effect == domain.RuleEffectExcluded || effect.Effect == domain.RuleEffectRequired) {
+			return &effects[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,340 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+	"time"
+)
+
+// ReassignReviews переназначает все ревью пользователей userIDs в открытых PR, выбирая замену
+// той же логикой, что и Reassign. Пользователи userIDs не назначаются ни на одно из освободившихся мест.
+// PR, для которых не нашлось кандидата, не меняются и попадают в отчёт со статусом NO_CANDIDATE.
+// Все изменения выполняются в одной транзакции.
+func (service *PullRequestService) ReassignReviews(
+	ctx context.Context,
+	userIDs []string,
+	reason string,
+) ([]domain.ReassignmentOutcome, error) {
+	var outcomes []domain.ReassignmentOutcome
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		assignments, err := service.repo.ListOpenReviews(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		outcomes, err = service.reassignAssignments(ctx, assignments, userIDs, reason, nil)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// ReassignStaleReviews переназначает ревью, которые ждут вердикта дольше auto_reassign_hours
+// команды ревьювера (в рабочих часах на момент now), той же логикой, что и Reassign.
+// Каждый PR переназначается в своей транзакции: сбой одного PR попадает в отчёт со статусом FAILED
+// и не мешает остальным. Если dryRun, замена только подбирается - без блокировок и записи в БД.
+func (service *PullRequestService) ReassignStaleReviews(
+	ctx context.Context,
+	now time.Time,
+	dryRun bool,
+) ([]domain.ReassignmentOutcome, error) {
+	reviews, err := service.repo.ListAutoReassignableReviews(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var assignments []domain.ReviewAssignment
+	for _, review := range reviews {
+		if review.IsStale(now) {
+			assignments = append(assignments, domain.ReviewAssignment{
+				PullRequestID: review.PullRequestID,
+				UserID:        review.ReviewerID,
+			})
+		}
+	}
+
+	if dryRun {
+		return service.planStaleReassignments(ctx, assignments)
+	}
+
+	outcomes := make([]domain.ReassignmentOutcome, 0, len(assignments))
+	for start := 0; start < len(assignments); {
+		end := start + 1
+		for end < len(assignments) && assignments[end].PullRequestID == assignments[start].PullRequestID {
+			end++
+		}
+		prAssignments := assignments[start:end]
+		start = end
+
+		var prOutcomes []domain.ReassignmentOutcome
+		err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			// вердикт мог прийти между чтением и блокировкой PR
+			var err error
+			prOutcomes, err = service.reassignAssignments(ctx, prAssignments, nil, domain.ReasonReviewStale, awaitingVerdict)
+			return err
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			for _, assignment := range prAssignments {
+				outcomes = append(outcomes, domain.ReassignmentOutcome{
+					PullRequestID:  assignment.PullRequestID,
+					ReplacedUserID: assignment.UserID,
+					Status:         domain.ReassignmentFailed,
+					Error:          err.Error(),
+				})
+			}
+			continue
+		}
+		outcomes = append(outcomes, prOutcomes...)
+	}
+
+	return outcomes, nil
+}
+
+// planStaleReassignments подбирает замену зависшим ревью assignments (упорядочены по PR), ничего не блокируя
+// и не меняя в БД. Нагрузка выбранных кандидатов учитывается в памяти, как при настоящем переназначении.
+func (service *PullRequestService) planStaleReassignments(
+	ctx context.Context,
+	assignments []domain.ReviewAssignment,
+) ([]domain.ReassignmentOutcome, error) {
+	members := newTeamMembersCache(service.teamRepo.GetTeamsMembersByTeamName)
+
+	var pr *domain.PullRequestDetails
+	outcomes := make([]domain.ReassignmentOutcome, 0, len(assignments))
+	for _, assignment := range assignments {
+		// следующие ревью того же PR подбираются с учётом уже выбранной замены
+		if pr == nil || pr.PullRequestID != assignment.PullRequestID {
+			var err error
+			pr, err = service.repo.GetByID(ctx, assignment.PullRequestID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !awaitingVerdict(pr, assignment.UserID) {
+			continue
+		}
+
+		outcome := domain.ReassignmentOutcome{
+			PullRequestID:  pr.PullRequestID,
+			ReplacedUserID: assignment.UserID,
+			Status:         domain.ReassignmentNoCandidate,
+		}
+
+		author, err := service.userRepo.GetByID(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		if author.TeamName == nil {
+			return nil, domain.ErrTeamNotFound
+		}
+		settings, err := service.teamRepo.GetTeamSettings(ctx, *author.TeamName)
+		if err != nil {
+			return nil, err
+		}
+
+		_, selection, err := service.planReplacement(ctx, pr, *author.TeamName, settings, assignment.UserID, nil, members.get)
+		if err != nil && !errors.Is(err, domain.ErrIsNoCandidates) {
+			return nil, err
+		}
+		if err == nil {
+			reviewers := slices.DeleteFunc(slices.Clone(pr.Reviewers), func(reviewer domain.Reviewer) bool {
+				return reviewer.UserID == assignment.UserID
+			})
+			for _, reviewerID := range selection.reviewers {
+				members.recordAssignment(reviewerID)
+				reviewers = append(reviewers, domain.Reviewer{UserID: reviewerID, State: domain.ReviewPending})
+			}
+			pr.Reviewers = reviewers
+
+			outcome.Status = domain.ReassignmentDone
+			outcome.NewReviewerID = &selection.reviewers[0]
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return outcomes, nil
+}
+
+// awaitingVerdict сообщает, что userID всё ещё ревьюит открытый PR и не отправил вердикт.
+func awaitingVerdict(pr *domain.PullRequestDetails, userID string) bool {
+	if pr.Status != domain.PROpenStatus {
+		return false
+	}
+	return slices.ContainsFunc(pr.Reviewers, func(reviewer domain.Reviewer) bool {
+		return reviewer.UserID == userID && reviewer.State == domain.ReviewPending
+	})
+}
+
+// reassignAssignments заменяет ревьюверов assignments (упорядочены по PR) в текущей транзакции.
+// Назначения, для которых keep после блокировки PR вернул false, пропускаются; nil - не пропускать.
+func (service *PullRequestService) reassignAssignments(
+	ctx context.Context,
+	assignments []domain.ReviewAssignment,
+	unavailable []string,
+	reason string,
+	keep func(pr *domain.PullRequestDetails, userID string) bool,
+) ([]domain.ReassignmentOutcome, error) {
+	// как и Reassign, блокируем PR раньше команд; PR идут по возрастанию id
+	var teams []string
+	for i, assignment := range assignments {
+		if i > 0 && assignments[i-1].PullRequestID == assignment.PullRequestID {
+			continue
+		}
+		if _, err := service.repo.LockPR(ctx, assignment.PullRequestID); err != nil {
+			return nil, err
+		}
+		pr, err := service.repo.GetByID(ctx, assignment.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+
+		prTeams, err := service.reviewerTeams(ctx, pr.AuthorID)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, prTeams...)
+	}
+
+	// команды всех PR блокируются сразу в порядке имён, иначе параллельные пакеты могут взаимоблокироваться
+	slices.Sort(teams)
+	if err := service.teamRepo.LockTeams(ctx, slices.Compact(teams)); err != nil {
+		return nil, err
+	}
+
+	// нагрузка участников читается один раз на команду и дальше обновляется в памяти
+	members := newTeamMembersCache(service.teamRepo.GetTeamsMembersByTeamName)
+
+	outcomes := make([]domain.ReassignmentOutcome, 0, len(assignments))
+	for _, assignment := range assignments {
+		pr, err := service.repo.GetByID(ctx, assignment.PullRequestID)
+		if err != nil {
+			return nil, err
+		}
+		if keep != nil && !keep(pr, assignment.UserID) {
+			continue
+		}
+
+		outcome, err := service.reassignReview(ctx, pr, assignment.UserID, unavailable, reason, members)
+		if err != nil {
+			return nil, err
+		}
+		outcomes = append(outcomes, *outcome)
+	}
+	return outcomes, nil
+}
+
+// reviewerTeams возвращает команду автора authorID и её резервные команды - откуда выбирается замена ревьювера.
+func (service *PullRequestService) reviewerTeams(ctx context.Context, authorID string) ([]string, error) {
+	author, err := service.userRepo.GetByID(ctx, authorID)
+	if err != nil {
+		return nil, err
+	}
+	if author.TeamName == nil {
+		return nil, nil
+	}
+
+	settings, err := service.teamRepo.GetTeamSettings(ctx, *author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	return append([]string{*author.TeamName}, settings.FallbackTeams...), nil
+}
+
+// reassignReview заменяет одного ревьювера в рамках пакетного переназначения; PR уже заблокирован.
+func (service *PullRequestService) reassignReview(
+	ctx context.Context,
+	pr *domain.PullRequestDetails,
+	replacedUserID string,
+	unavailable []string,
+	reason string,
+	members *teamMembersCache,
+) (*domain.ReassignmentOutcome, error) {
+	outcome := &domain.ReassignmentOutcome{
+		PullRequestID:  pr.PullRequestID,
+		ReplacedUserID: replacedUserID,
+		Status:         domain.ReassignmentNoCandidate,
+	}
+
+	prAssignments, err := service.replaceReviewer(ctx, pr, replacedUserID, unavailable, reason, members.get)
+	if err != nil {
+		if errors.Is(err, domain.ErrIsNoCandidates) {
+			return outcome, nil
+		}
+		return nil, err
+	}
+
+	previous := pr.ReviewerIDs()
+	for _, reviewerID := range prAssignments.AssignedReviewers {
+		if !slices.Contains(previous, reviewerID) {
+			members.recordAssignment(reviewerID)
+			if outcome.NewReviewerID == nil {
+				outcome.NewReviewerID = &reviewerID
+			}
+		}
+	}
+	outcome.Status = domain.ReassignmentDone
+	outcome.Assignment = prAssignments
+
+	return outcome, nil
+}
+
+// teamMembersCache хранит участников команд на время пакетного переназначения,
+// чтобы не пересчитывать нагрузку запросом к БД на каждый PR.
+type teamMembersCache struct {
+	load    teamMembersFunc
+	members map[string][]domain.Member
+}
+
+func newTeamMembersCache(load teamMembersFunc) *teamMembersCache {
+	return &teamMembersCache{
+		load:    load,
+		members: make(map[string][]domain.Member),
+	}
+}
+
+// get возвращает участников команды, загружая их при первом обращении.
+func (cache *teamMembersCache) get(ctx context.Context, teamName string) ([]domain.Member, error) {
+	if members, ok := cache.members[teamName]; ok {
+		return members, nil
+	}
+
+	members, err := cache.load(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	cache.members[teamName] = members
+	return members, nil
+}
+
+// recordAssignment учитывает новое назначение в нагрузке участника, чтобы следующий выбор её видел.
+func (cache *teamMembersCache) recordAssignment(userID string) {
+	now := time.Now()
+	for _, members := range cache.members {
+		for i := range members {
+			member := &members[i]
+			if member.UserID != userID {
+				continue
+			}
+
+			member.PRReviews = increment(member.PRReviews)
+			member.OpenReviews = increment(member.OpenReviews)
+			if member.Load != nil {
+				load := *member.Load + 1
+				member.Load = &load
+			}
+			member.LastAssignedAt = &now
+		}
+	}
+}
+
+func increment(value *int64) *int64 {
+	next := valueOrZero(value) + 1
+	return &next
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"time"
+)
+
+// OverdueReviewsByUser возвращает назначения пользователя, по которым истёк SLA его команды.
+func (service *PullRequestService) OverdueReviewsByUser(ctx context.Context, userID string) ([]domain.AssignedReview, error) {
+	if _, err := service.userRepo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	reviews, err := service.GetReview(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return overdueOnly(reviews), nil
+}
+
+// OverdueReviewsByTeam возвращает назначения участников команды, по которым истёк SLA команды.
+// Если у команды нет SLA, список пуст.
+func (service *PullRequestService) OverdueReviewsByTeam(ctx context.Context, teamName string) ([]domain.AssignedReview, error) {
+	isTeamExists, err := service.teamRepo.IsTeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !isTeamExists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	reviews, err := service.repo.ListPendingReviewsByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for i := range reviews {
+		reviews[i].ApplySLA(now)
+	}
+	return overdueOnly(reviews), nil
+}
+
+// overdueOnly оставляет только просроченные назначения, сохраняя порядок.
+func overdueOnly(reviews []domain.AssignedReview) []domain.AssignedReview {
+	overdue := make([]domain.AssignedReview, 0, len(reviews))
+	for _, review := range reviews {
+		if review.Overdue {
+			overdue = append(overdue, review)
+		}
+	}
+	return overdue
+}
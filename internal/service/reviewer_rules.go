@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+)
+
+// ReviewerRuleRepository хранит правила исключения и обязательных ревьюверов для авторов команды.
+type ReviewerRuleRepository interface {
+	Add(ctx context.Context, rule domain.ReviewerRule) (*domain.ReviewerRule, error)
+	ListByTeam(ctx context.Context, teamName string) ([]domain.ReviewerRule, error)
+	Delete(ctx context.Context, teamName string, id int64) error
+}
+
+// AddReviewerRule сохраняет правило команды rule.TeamName. Автор правила должен состоять в команде,
+// тег REQUIRES_TAG приводится к нижнему регистру.
+func (service *TeamService) AddReviewerRule(ctx context.Context, rule domain.ReviewerRule) (*domain.ReviewerRule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	if rule.Tag != nil {
+		tags, err := domain.NormalizeTags([]string{*rule.Tag})
+		if err != nil {
+			return nil, domain.ErrInvalidReviewerRule
+		}
+		rule.Tag = &tags[0]
+	}
+
+	isTeamExists, err := service.teamRepo.IsTeamExists(ctx, rule.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	if !isTeamExists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	author, err := service.userRepo.GetByID(ctx, rule.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if author.TeamName == nil || *author.TeamName != rule.TeamName {
+		return nil, domain.ErrUserNotInTeam
+	}
+
+	return service.ruleRepo.Add(ctx, rule)
+}
+
+// ListReviewerRules возвращает правила команды в порядке добавления.
+func (service *TeamService) ListReviewerRules(ctx context.Context, teamName string) ([]domain.ReviewerRule, error) {
+	isTeamExists, err := service.teamRepo.IsTeamExists(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	if !isTeamExists {
+		return nil, domain.ErrTeamNotFound
+	}
+
+	return service.ruleRepo.ListByTeam(ctx, teamName)
+}
+
+// DeleteReviewerRule удаляет правило команды.
+func (service *TeamService) DeleteReviewerRule(ctx context.Context, teamName string, id int64) error {
+	return service.ruleRepo.Delete(ctx, teamName, id)
+}
+
+// applyExclusionRules читает правила команды teamName для автора authorID и добавляет в exclude
+// всех, кому NEVER_REVIEWS запрещает ревьюить автора. Возвращает правила REQUIRES_TAG и
+// EXCLUDED для тех, кого исключило именно правило.
+func (service *PullRequestService) applyExclusionRules(
+	ctx context.Context,
+	teamName, authorID string,
+	exclude map[string]struct{},
+) ([]domain.ReviewerRule, []domain.RuleEffect, error) {
+	rules, err := service.ruleRepo.ListByTeam(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		required []domain.ReviewerRule
+		effects  []domain.RuleEffect
+	)
+	for _, rule := range rules {
+		if rule.AuthorID != authorID {
+			continue
+		}
+
+		switch rule.Type {
+		case domain.RuleNeverReviews:
+			if _, ok := exclude[*rule.ReviewerID]; ok {
+				continue
+			}
+			exclude[*rule.ReviewerID] = struct{}{}
+			effects = append(effects, domain.RuleEffect{
+				RuleID: rule.ID,
+				Type:   rule.Type,
+				UserID: *rule.ReviewerID,
+				Effect: domain.RuleEffectExcluded,
+			})
+		case domain.RuleRequiresTag:
+			required = append(required, rule)
+		}
+	}
+
+	return required, effects, nil
+}
+
+// pickRequiredReviewers выполняет правила REQUIRES_TAG до балансировки нагрузки: если никто из assigned
+// не имеет нужного тега, выбирается один участник с этим тегом (команда автора, затем резервные)
+// по стратегии из settings. Выбранные добавляются в criteria.exclude.
+func (service *PullRequestService) pickRequiredReviewers(
+	ctx context.Context,
+	members teamMembersFunc,
+	teamName string,
+	settings *domain.TeamSettings,
+	rules []domain.ReviewerRule,
+	assigned []string,
+	criteria selectionCriteria,
+) (*reviewerSelection, error) {
+	selection := &reviewerSelection{}
+	if len(rules) == 0 {
+		return selection, nil
+	}
+
+	tagsByUser := make(map[string][]string, len(assigned))
+	addTags := func(userID string) error {
+		user, err := service.userRepo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		tagsByUser[userID] = user.Tags
+		return nil
+	}
+	for _, userID := range assigned {
+		if err := addTags(userID); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, rule := range rules {
+		tag := *rule.Tag
+		effect := domain.RuleEffect{RuleID: rule.ID, Type: rule.Type, Effect: domain.RuleEffectUnsatisfied}
+
+		for _, userID := range slices.Concat(assigned, selection.reviewers) {
+			if slices.Contains(tagsByUser[userID], tag) {
+				effect.UserID = userID
+				effect.Effect = domain.RuleEffectSatisfied
+				break
+			}
+		}
+
+		if effect.Effect == domain.RuleEffectUnsatisfied {
+			withTag := criteria
+			withTag.only = func(member domain.Member) bool {
+				return member.HasAnyTag([]string{tag})
+			}
+
+			picked, err := service.pickReviewers(ctx, members, teamName, settings, withTag, 1)
+			if err != nil {
+				return nil, err
+			}
+			if len(picked.reviewers) > 0 {
+				userID := picked.reviewers[0]
+				if err := addTags(userID); err != nil {
+					return nil, err
+				}
+				criteria.exclude[userID] = struct{}{}
+				selection.merge(picked)
+
+				effect.UserID = userID
+				effect.Effect = domain.RuleEffectRequired
+			}
+		}
+
+		selection.effects = append(selection.effects, effect)
+	}
+
+	return selection, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"cmp"
+	"math/rand/v2"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ReviewerSelector выбирает до count ревьюверов из кандидатов.
+// Кандидаты приходят уже отфильтрованными: автор, неактивные и уже назначенные исключены.
+type ReviewerSelector interface {
+	Select(candidates []domain.Member, count int) []domain.Member
+}
+
+// NewReviewerSelector возвращает встроенную реализацию для стратегии команды.
+// Пустая стратегия трактуется как domain.DefaultSelectionStrategy.
+func NewReviewerSelector(strategy domain.SelectionStrategy) (ReviewerSelector, error) {
+	switch strategy {
+	case "", domain.StrategyLeastLoaded:
+		return LeastLoadedSelector{}, nil
+	case domain.StrategyRoundRobin:
+		return RoundRobinSelector{}, nil
+	case domain.StrategyWeightedRandom:
+		return NewWeightedRandomSelector(nil), nil
+	case domain.StrategyLeastOpenReviews:
+		return LeastOpenReviewsSelector{}, nil
+	default:
+		return nil, domain.ErrUnknownSelectionStrategy
+	}
+}
+
+// LeastLoadedSelector выбирает кандидатов с минимальной нагрузкой по метрике команды.
+type LeastLoadedSelector struct{}
+
+func (LeastLoadedSelector) Select(candidates []domain.Member, count int) []domain.Member {
+	return takeSorted(candidates, count, func(a, b domain.Member) int {
+		return cmp.Compare(memberLoad(a), memberLoad(b))
+	})
+}
+
+// LeastOpenReviewsSelector выбирает кандидатов с минимальным числом открытых ревью.
+type LeastOpenReviewsSelector struct{}
+
+func (LeastOpenReviewsSelector) Select(candidates []domain.Member, count int) []domain.Member {
+	return takeSorted(candidates, count, func(a, b domain.Member) int {
+		return cmp.Compare(valueOrZero(a.OpenReviews), valueOrZero(b.OpenReviews))
+	})
+}
+
+// RoundRobinSelector обходит команду по кругу: первыми идут те,
+// кому ревью не назначались дольше всех (или не назначались вовсе).
+type RoundRobinSelector struct{}
+
+func (RoundRobinSelector) Select(candidates []domain.Member, count int) []domain.Member {
+	return takeSorted(candidates, count, func(a, b domain.Member) int {
+		return compareTime(a.LastAssignedAt, b.LastAssignedAt)
+	})
+}
+
+// WeightedRandomSelector выбирает кандидатов случайно без повторов,
+// вес кандидата равен 1 / (1 + нагрузка по метрике команды).
+type WeightedRandomSelector struct {
+	random func() float64
+}
+
+// NewWeightedRandomSelector создаёт селектор с заданным источником случайных чисел в [0, 1).
+// Если источник не передан, используется math/rand/v2.
+func NewWeightedRandomSelector(random func() float64) WeightedRandomSelector {
+	if random == nil {
+		random = rand.Float64
+	}
+	return WeightedRandomSelector{random: random}
+}
+
+func (s WeightedRandomSelector) Select(candidates []domain.Member, count int) []domain.Member {
+	pool := slices.Clone(candidates)
+	slices.SortStableFunc(pool, func(a, b domain.Member) int {
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	var selected []domain.Member
+	for len(selected) < count && len(pool) > 0 {
+		weights := make([]float64, len(pool))
+		var total float64
+		for i, member := range pool {
+			weights[i] = 1 / (1 + memberLoad(member))
+			total += weights[i]
+		}
+
+		point := s.random() * total
+		idx := len(pool) - 1
+		for i, weight := range weights {
+			if point < weight {
+				idx = i
+				break
+			}
+			point -= weight
+		}
+
+		selected = append(selected, pool[idx])
+		pool = slices.Delete(pool, idx, idx+1)
+	}
+
+	return selected
+}
+
+// takeSorted стабильно сортирует копию кандидатов и возвращает первые count.
+// При равенстве ключа порядок определяется user_id, чтобы выбор был детерминированным.
+func takeSorted(candidates []domain.Member, count int, compare func(a, b domain.Member) int) []domain.Member {
+	sorted := slices.Clone(candidates)
+	slices.SortStableFunc(sorted, func(a, b domain.Member) int {
+		if c := compare(a, b); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	if count < len(sorted) {
+		sorted = sorted[:max(count, 0)]
+	}
+	return sorted
+}
+
+// memberLoad возвращает нагрузку участника по метрике команды,
+// а если она не посчитана - общее число назначенных ревью.
+func memberLoad(member domain.Member) float64 {
+	if member.Load != nil {
+		return *member.Load
+	}
+	return float64(valueOrZero(member.PRReviews))
+}
+
+func valueOrZero(v *int64) int64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
+
+// compareTime упорядочивает моменты по возрастанию, nil считается самым ранним.
+func compareTime(a, b *time.Time) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	return a.Compare(*b)
+}
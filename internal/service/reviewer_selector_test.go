@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"errors"
+	"pr-reviewer-assigment-service/internal/domain"
+	"pr-reviewer-assigment-service/internal/service"
+	"slices"
+	"testing"
+	"time"
+)
+
+func member(id string, reviews, open int64, lastAssigned *time.Time) domain.Member {
+	return domain.Member{
+		UserID:         id,
+		IsActive:       true,
+		PRReviews:      &reviews,
+		OpenReviews:    &open,
+		LastAssignedAt: lastAssigned,
+	}
+}
+
+func ids(members []domain.Member) []string {
+	result := make([]string, 0, len(members))
+	for _, m := range members {
+		result = append(result, m.UserID)
+	}
+	return result
+}
+
+func TestReviewerSelectors(t *testing.T) {
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+	dayAgo := now.Add(-24 * time.Hour)
+
+	candidates := []domain.Member{
+		member("u1", 10, 0, &now),
+		member("u2", 1, 3, &dayAgo),
+		member("u3", 5, 1, nil),
+		member("u4", 1, 2, &hourAgo),
+	}
+
+	tests := []struct {
+		name     string
+		strategy domain.SelectionStrategy
+		want     []string
+	}{
+		{name: "least loaded", strategy: domain.StrategyLeastLoaded, want: []string{"u2", "u4"}},
+		{name: "default", strategy: "", want: []string{"u2", "u4"}},
+		{name: "least open reviews", strategy: domain.StrategyLeastOpenReviews, want: []string{"u1", "u3"}},
+		{name: "round robin", strategy: domain.StrategyRoundRobin, want: []string{"u3", "u2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selector, err := service.NewReviewerSelector(tt.strategy)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			got := ids(selector.Select(candidates, 2))
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestWeightedRandomSelector(t *testing.T) {
+	candidates := []domain.Member{
+		member("u1", 0, 0, nil),
+		member("u2", 3, 0, nil),
+		member("u3", 1, 0, nil),
+	}
+
+	// weights: u1=1, u2=0.25, u3=0.5; 0.9 of total lands on u3, then 0 picks u1.
+	values := []float64{0.9, 0}
+	selector := service.NewWeightedRandomSelector(func() float64 {
+		if len(values) == 0 {
+			return 0
+		}
+		v := values[0]
+		values = values[1:]
+		return v
+	})
+
+	got := ids(selector.Select(candidates, 2))
+	if want := []string{"u3", "u1"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	if got := selector.Select(candidates[:1], 5); len(got) != 1 {
+		t.Fatalf("expected selection to be bounded by candidates, got %d", len(got))
+	}
+}
+
+func TestUnknownStrategy(t *testing.T) {
+	if _, err := service.NewReviewerSelector("RANDOM_GUESS"); !errors.Is(err, domain.ErrUnknownSelectionStrategy) {
+		t.Fatalf("expected ErrUnknownSelectionStrategy, got %v", err)
+	}
+}
+
+func TestLeastLoadedPrefersTeamLoadMetric(t *testing.T) {
+	veteranLoad, newcomerLoad := 0.5, 3.0
+	veteran := member("veteran", 500, 0, nil)
+	veteran.Load = &veteranLoad
+	newcomer := member("newcomer", 3, 3, nil)
+	newcomer.Load = &newcomerLoad
+
+	got := ids(service.LeastLoadedSelector{}.Select([]domain.Member{newcomer, veteran}, 1))
+	if want := []string{"veteran"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
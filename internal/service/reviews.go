@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"pr-reviewer-assigment-service/internal/domain"
+	"strings"
+)
+
+// SubmitReview сохраняет вердикт ревьювера reviewerID по открытому PR: APPROVED, CHANGES_REQUESTED
+// или DISMISSED (отзыв прежнего вердикта). Повторный вердикт заменяет предыдущий; версия PR увеличивается,
+// в историю пишется REVIEWED. Если в ctx есть исполнитель (WithActor), он должен совпадать с reviewerID,
+// иначе возвращается domain.ErrNotReviewer.
+func (service *PullRequestService) SubmitReview(
+	ctx context.Context,
+	prID, reviewerID string,
+	state domain.ReviewState,
+) (*domain.PullRequestDetails, error) {
+	if !state.IsVerdict() {
+		return nil, domain.ErrInvalidReviewState
+	}
+	if actorID := actorFromContext(ctx); actorID != nil && *actorID != reviewerID {
+		return nil, domain.ErrNotReviewer
+	}
+
+	var pr *domain.PullRequestDetails
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := service.lockOpenPR(ctx, prID, nil); err != nil {
+			return err
+		}
+
+		if err := service.repo.SetReviewState(ctx, prID, reviewerID, state); err != nil {
+			return err
+		}
+
+		reason := strings.ToLower(string(state))
+		event := service.newEvent(ctx, prID, domain.AssignmentEventReviewed, reviewerID, nil, reason)
+		if err := service.recordEvents(ctx, []domain.AssignmentEvent{event}); err != nil {
+			return err
+		}
+
+		if _, err := service.repo.BumpVersion(ctx, prID); err != nil {
+			return err
+		}
+
+		var err error
+		pr, err = service.repo.GetByID(ctx, prID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// checkApprovals проверяет, что у открытого PR не меньше одобрений, чем required_approvals команды автора.
+// Смерженный и закрытый PR не проверяются: их обрабатывает repo.Merge.
+func (service *PullRequestService) checkApprovals(ctx context.Context, prID string) error {
+	pr, err := service.repo.GetByID(ctx, prID)
+	if err != nil {
+		return err
+	}
+	if pr.Status != domain.PROpenStatus {
+		return nil
+	}
+
+	author, err := service.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return err
+	}
+	if author.TeamName == nil {
+		return nil
+	}
+
+	settings, err := service.teamRepo.GetTeamSettings(ctx, *author.TeamName)
+	if err != nil {
+		return err
+	}
+
+	if settings.RequiredApprovals != nil && pr.Approvals() < *settings.RequiredApprovals {
+		return domain.ErrNotApproved
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
 )
 
 type TeamRepository interface {
@@ -12,27 +13,138 @@ type TeamRepository interface {
 	UpdateTeamMembers(ctx context.Context, team *domain.Team) error
 	GetTeam(ctx context.Context, teamName string) (*domain.Team, error)
 	GetTeamsMembersByTeamName(ctx context.Context, teamName string) ([]domain.Member, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*domain.TeamSettings, error)
+	LockTeams(ctx context.Context, teamNames []string) error
+	DeactivateMembers(ctx context.Context, teamName string, userIDs []string) ([]string, []string, error)
 }
 
 type TeamService struct {
-	userRepo UserRepository
-	teamRepo TeamRepository
+	userRepo      UserRepository
+	teamRepo      TeamRepository
+	codeOwnerRepo CodeOwnerRepository
+	ruleRepo      ReviewerRuleRepository
+	reassigner    ReviewReassigner
+	domainEvents  DomainEventRepository
+	tx            Transactor
 }
 
-func NewTeamService(teamRepo TeamRepository, userRepo UserRepository) *TeamService {
+func NewTeamService(
+	teamRepo TeamRepository,
+	userRepo UserRepository,
+	codeOwnerRepo CodeOwnerRepository,
+	ruleRepo ReviewerRuleRepository,
+	reassigner ReviewReassigner,
+	domainEvents DomainEventRepository,
+	tx Transactor,
+) *TeamService {
 	return &TeamService{
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		userRepo:      userRepo,
+		teamRepo:      teamRepo,
+		codeOwnerRepo: codeOwnerRepo,
+		ruleRepo:      ruleRepo,
+		reassigner:    reassigner,
+		domainEvents:  domainEvents,
+		tx:            tx,
 	}
 }
 
 func (service *TeamService) Add(ctx context.Context, team domain.Team) (*domain.Team, error) {
+	if team.SelectionStrategy != "" && !team.SelectionStrategy.IsValid() {
+		return nil, domain.ErrUnknownSelectionStrategy
+	}
+	if team.RequiredReviewers < 0 || team.RequiredReviewers > domain.MaxReviewers {
+		return nil, domain.ErrInvalidReviewersCount
+	}
+	if approvals := team.RequiredApprovals; approvals != nil && (*approvals < 0 || *approvals > domain.MaxReviewers) {
+		return nil, domain.ErrInvalidRequiredApprovals
+	}
+	if team.ReviewSLAHours != nil && *team.ReviewSLAHours < 0 {
+		return nil, domain.ErrInvalidReviewSLA
+	}
+	if team.AutoReassignHours != nil && *team.AutoReassignHours < 0 {
+		return nil, domain.ErrInvalidAutoReassign
+	}
+	if team.LoadMetric != "" && !team.LoadMetric.IsValid() || team.LoadWindowHours < 0 || team.LoadHalfLifeHours < 0 {
+		return nil, domain.ErrUnknownLoadMetric
+	}
+
+	if slices.Contains(team.FallbackTeams, team.TeamName) {
+		return nil, domain.ErrInvalidFallbackTeam
+	}
+	if team.FallbackTeams != nil {
+		// повторы не меняют приоритет: остаётся первое вхождение
+		fallbackTeams := make([]string, 0, len(team.FallbackTeams))
+		for _, name := range team.FallbackTeams {
+			if !slices.Contains(fallbackTeams, name) {
+				fallbackTeams = append(fallbackTeams, name)
+			}
+		}
+		team.FallbackTeams = fallbackTeams
+	}
+
+	for i, member := range team.Members {
+		if member.MaxOpenReviews != nil && *member.MaxOpenReviews < 0 {
+			return nil, domain.ErrInvalidCapacity
+		}
+		tags, err := domain.NormalizeTags(member.Tags)
+		if err != nil {
+			return nil, err
+		}
+		team.Members[i].Tags = tags
+	}
+
+	var saved *domain.Team
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = service.save(ctx, team)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// save создаёт команду или обновляет состав существующей; вызывается в транзакции Add,
+// чтобы изменения пользователей и команды записывались вместе с их событиями.
+// Ревью участников, деактивированных запросом, переназначаются в той же транзакции.
+func (service *TeamService) save(ctx context.Context, team domain.Team) (*domain.Team, error) {
 	isTeamExists, err := service.teamRepo.IsTeamExists(ctx, team.TeamName)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, member := range team.Members {
+	var (
+		previousMembers []domain.Member
+		events          []domain.DomainEvent
+		// deactivated - участники, которых запрос переводит из активных в неактивные
+		deactivated []string
+	)
+	// запрос может менять только часть рабочего дня, поэтому он проверяется вместе с текущими настройками
+	businessDay := team.TeamSettings
+	if isTeamExists {
+		previous, err := service.teamRepo.GetTeam(ctx, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
+		previousMembers = previous.Members
+
+		if businessDay.BusinessDayStart == nil {
+			businessDay.BusinessDayStart = previous.BusinessDayStart
+		}
+		if businessDay.BusinessDayEnd == nil {
+			businessDay.BusinessDayEnd = previous.BusinessDayEnd
+		}
+		if businessDay.BusinessTimeZone == "" {
+			businessDay.BusinessTimeZone = previous.BusinessTimeZone
+		}
+	}
+	if _, err := businessDay.BusinessHours(); err != nil {
+		return nil, err
+	}
+
+	for i, member := range team.Members {
 		user, err := service.userRepo.GetByID(ctx, member.UserID)
 		if err != nil {
 			if !errors.Is(err, domain.ErrUserNotFound) {
@@ -48,7 +160,33 @@ func (service *TeamService) Add(ctx context.Context, team domain.Team) (*domain.
 			return nil, domain.ErrUserAlreadyInTeam
 		}
 
+		// в запросе nil оставляет лимит и теги прежними, 0 снимает лимит
+		if member.MaxOpenReviews != nil || member.Tags != nil {
+			if err := applyMaxOpenReviews(user, member.MaxOpenReviews); err != nil {
+				return nil, err
+			}
+			if member.Tags != nil {
+				user.Tags = member.Tags
+			}
+			if err := service.userRepo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+		team.Members[i].MaxOpenReviews = user.MaxOpenReviews
+		team.Members[i].Tags = user.Tags
+
 		if isTeamExists {
+			if user.IsActive != member.IsActive {
+				event, err := userActivityEvent(ctx, user.ID, &team.TeamName, member.IsActive)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, event)
+				if !member.IsActive {
+					deactivated = append(deactivated, user.ID)
+				}
+			}
+
 			user.IsActive = member.IsActive
 			if err = service.userRepo.UpdateActive(ctx, user); err != nil {
 				return nil, err
@@ -56,28 +194,65 @@ func (service *TeamService) Add(ctx context.Context, team domain.Team) (*domain.
 		}
 	}
 
+	membership, changed, err := membershipEvent(ctx, team.TeamName, previousMembers, team.Members)
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		events = append(events, membership)
+	}
+
 	if isTeamExists {
 		if err := service.teamRepo.UpdateTeamMembers(ctx, &team); err != nil {
 			return nil, err
 		}
+		if err := service.domainEvents.Append(ctx, events); err != nil {
+			return nil, err
+		}
+
+		// как и DeactivateUsers, деактивированные через /team/add не должны держать открытые ревью
+		if len(deactivated) > 0 {
+			if _, err := service.reassigner.ReassignReviews(ctx, deactivated, domain.ReasonReviewerDeactivated); err != nil {
+				return nil, err
+			}
+		}
+
+		settings, err := service.teamRepo.GetTeamSettings(ctx, team.TeamName)
+		if err != nil {
+			return nil, err
+		}
 
 		updatedTeam := domain.Team{
-			TeamName: team.TeamName,
+			TeamName:     team.TeamName,
+			TeamSettings: *settings,
 		}
 		for _, member := range team.Members {
 			updatedTeam.Members = append(updatedTeam.Members, domain.Member{
-				UserID:   member.UserID,
-				Username: member.Username,
-				IsActive: member.IsActive,
+				UserID:         member.UserID,
+				Username:       member.Username,
+				IsActive:       member.IsActive,
+				MaxOpenReviews: member.MaxOpenReviews,
+				Tags:           member.Tags,
 			})
 		}
 
 		return &updatedTeam, nil
 	}
 
+	team.TeamSettings = team.TeamSettings.WithDefaults()
+	if team.ReviewSLAHours != nil && *team.ReviewSLAHours == 0 {
+		team.ReviewSLAHours = nil
+	}
+	if team.AutoReassignHours != nil && *team.AutoReassignHours == 0 {
+		team.AutoReassignHours = nil
+	}
+
 	if err = service.teamRepo.CreateTeam(ctx, &team); err != nil {
 		return nil, err
 	}
+	if err := service.domainEvents.Append(ctx, events); err != nil {
+		return nil, err
+	}
 
 	return &team, nil
 }
@@ -98,3 +273,54 @@ func (service *TeamService) GetTeam(ctx context.Context, teamName string) (*doma
 
 	return team, nil
 }
+
+// DeactivateUsers деактивирует участников команды userIDs и в той же транзакции переназначает
+// их ревью в открытых PR на оставшихся активных участников; деактивируемые не назначаются никуда.
+// Если кто-то из userIDs не состоит в команде, ничего не меняется и возвращается domain.ErrUserNotInTeam.
+// USER_DEACTIVATED публикуется только для тех, кто был активен.
+func (service *TeamService) DeactivateUsers(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) ([]string, []domain.ReassignmentOutcome, error) {
+	userIDs = slices.Compact(slices.Sorted(slices.Values(userIDs)))
+
+	isTeamExists, err := service.teamRepo.IsTeamExists(ctx, teamName)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !isTeamExists {
+		return nil, nil, domain.ErrTeamNotFound
+	}
+
+	var outcomes []domain.ReassignmentOutcome
+	err = service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		members, deactivated, err := service.teamRepo.DeactivateMembers(ctx, teamName, userIDs)
+		if err != nil {
+			return err
+		}
+		if len(members) != len(userIDs) {
+			return domain.ErrUserNotInTeam
+		}
+
+		events := make([]domain.DomainEvent, 0, len(deactivated))
+		for _, userID := range deactivated {
+			event, err := userActivityEvent(ctx, userID, &teamName, false)
+			if err != nil {
+				return err
+			}
+			events = append(events, event)
+		}
+		if err := service.domainEvents.Append(ctx, events); err != nil {
+			return err
+		}
+
+		outcomes, err = service.reassigner.ReassignReviews(ctx, userIDs, domain.ReasonReviewerDeactivated)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return userIDs, outcomes, nil
+}
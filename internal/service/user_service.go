@@ -3,35 +3,179 @@ package service
 import (
 	"context"
 	"pr-reviewer-assigment-service/internal/domain"
+	"time"
 )
 
 type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	UpdateActive(ctx context.Context, user *domain.User) error
+	Update(ctx context.Context, user *domain.User) error
 	Create(ctx context.Context, userID, name string, isActive *bool) (*domain.User, error)
 }
 
+// UnavailabilityRepository хранит запланированные периоды отсутствия пользователей.
+type UnavailabilityRepository interface {
+	Add(ctx context.Context, period domain.Unavailability) (*domain.Unavailability, error)
+	ListByUser(ctx context.Context, userID string) ([]domain.Unavailability, error)
+	Delete(ctx context.Context, userID string, id int64) error
+}
+
+// ReviewReassigner переназначает открытые ревью пользователей, выбывающих из ротации.
+type ReviewReassigner interface {
+	ReassignReviews(ctx context.Context, userIDs []string, reason string) ([]domain.ReassignmentOutcome, error)
+}
+
 type UserService struct {
-	repo UserRepository
+	repo               UserRepository
+	unavailabilityRepo UnavailabilityRepository
+	reassigner         ReviewReassigner
+	domainEvents       DomainEventRepository
+	tx                 Transactor
 }
 
-func NewUserService(repo UserRepository) *UserService {
+func NewUserService(
+	repo UserRepository,
+	unavailabilityRepo UnavailabilityRepository,
+	reassigner ReviewReassigner,
+	domainEvents DomainEventRepository,
+	tx Transactor,
+) *UserService {
 	return &UserService{
-		repo: repo,
+		repo:               repo,
+		unavailabilityRepo: unavailabilityRepo,
+		reassigner:         reassigner,
+		domainEvents:       domainEvents,
+		tx:                 tx,
+	}
+}
+
+// SetIsActive меняет активность пользователя. При деактивации его ревью в открытых PR
+// в той же транзакции переназначаются на других участников; отчёт об этом возвращается вторым значением.
+func (service *UserService) SetIsActive(
+	ctx context.Context,
+	userID string,
+	isActive bool,
+) (*domain.User, []domain.ReassignmentOutcome, error) {
+	var (
+		user     *domain.User
+		outcomes []domain.ReassignmentOutcome
+	)
+	err := service.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = service.repo.GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		changed := user.IsActive != isActive
+		user.IsActive = isActive
+
+		if err = service.repo.UpdateActive(ctx, user); err != nil {
+			return err
+		}
+
+		if changed {
+			event, err := userActivityEvent(ctx, user.ID, user.TeamName, user.IsActive)
+			if err != nil {
+				return err
+			}
+			if err := service.domainEvents.Append(ctx, []domain.DomainEvent{event}); err != nil {
+				return err
+			}
+		}
+
+		if isActive {
+			return nil
+		}
+		outcomes, err = service.reassigner.ReassignReviews(ctx, []string{userID}, domain.ReasonReviewerDeactivated)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
+
+	return user, outcomes, nil
 }
 
-func (service *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+// AddUnavailability планирует период отсутствия пользователя [startsAt, endsAt).
+func (service *UserService) AddUnavailability(
+	ctx context.Context,
+	userID string,
+	startsAt, endsAt time.Time,
+	reason string,
+) (*domain.Unavailability, error) {
+	if !endsAt.After(startsAt) {
+		return nil, domain.ErrInvalidUnavailability
+	}
+
+	return service.unavailabilityRepo.Add(ctx, domain.Unavailability{
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   reason,
+	})
+}
+
+// ListUnavailability возвращает текущие и будущие периоды отсутствия пользователя.
+func (service *UserService) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	if _, err := service.repo.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return service.unavailabilityRepo.ListByUser(ctx, userID)
+}
+
+// DeleteUnavailability отменяет период отсутствия пользователя.
+func (service *UserService) DeleteUnavailability(ctx context.Context, userID string, id int64) error {
+	return service.unavailabilityRepo.Delete(ctx, userID, id)
+}
+
+// Update меняет имя, лимит открытых ревью и теги пользователя.
+// username "", maxOpenReviews nil и tags nil оставляют значения без изменений, maxOpenReviews 0 снимает лимит.
+func (service *UserService) Update(
+	ctx context.Context,
+	userID, username string,
+	maxOpenReviews *int,
+	tags []string,
+) (*domain.User, error) {
+	tags, err := domain.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
 	user, err := service.repo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	user.IsActive = isActive
+	if username != "" {
+		user.Username = username
+	}
+	if err := applyMaxOpenReviews(user, maxOpenReviews); err != nil {
+		return nil, err
+	}
+	if tags != nil {
+		user.Tags = tags
+	}
 
-	if err = service.repo.UpdateActive(ctx, user); err != nil {
+	if err := service.repo.Update(ctx, user); err != nil {
 		return nil, err
 	}
 
 	return user, nil
 }
+
+// applyMaxOpenReviews применяет лимит открытых ревью из запроса: nil - не менять, 0 - без ограничения.
+func applyMaxOpenReviews(user *domain.User, maxOpenReviews *int) error {
+	switch {
+	case maxOpenReviews == nil:
+	case *maxOpenReviews < 0:
+		return domain.ErrInvalidCapacity
+	case *maxOpenReviews == 0:
+		user.MaxOpenReviews = nil
+	default:
+		limit := *maxOpenReviews
+		user.MaxOpenReviews = &limit
+	}
+	return nil
+}
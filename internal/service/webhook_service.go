@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"pr-reviewer-assigment-service/internal/domain"
+	"slices"
+	"time"
+)
+
+// WebhookRepository хранит подписки на вебхуки и outbox их доставок.
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, subscription domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	Enqueue(ctx context.Context, deliveries []domain.WebhookDelivery) error
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]domain.WebhookDelivery, error)
+	SaveAttempt(ctx context.Context, delivery domain.WebhookDelivery) error
+	ListDeadLetters(ctx context.Context, subscriptionID *int64, limit int) ([]domain.WebhookDelivery, error)
+}
+
+// WebhookSender отправляет доставку подписчику; ошибка - подписчик не ответил 2xx.
+type WebhookSender interface {
+	Send(ctx context.Context, delivery domain.WebhookDelivery) error
+}
+
+// webhookClaimLease - на сколько выбранная доставка скрывается от других экземпляров.
+// Доставки выбираются по одной, поэтому аренда должна покрывать одну отправку и быть больше её таймаута.
+const webhookClaimLease = 2 * time.Minute
+
+type WebhookService struct {
+	repo   WebhookRepository
+	sender WebhookSender
+	now    func() time.Time
+}
+
+func NewWebhookService(repo WebhookRepository, sender WebhookSender) *WebhookService {
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+		now:    time.Now,
+	}
+}
+
+// Subscribe сохраняет подписку; повторы в списке событий отбрасываются.
+func (service *WebhookService) Subscribe(
+	ctx context.Context,
+	subscription domain.WebhookSubscription,
+) (*domain.WebhookSubscription, error) {
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	subscription.EventTypes = slices.Compact(slices.Sorted(slices.Values(subscription.EventTypes)))
+
+	return service.repo.CreateSubscription(ctx, subscription)
+}
+
+// ListSubscriptions возвращает все подписки в порядке создания.
+func (service *WebhookService) ListSubscriptions(ctx context.Context) ([]domain.WebhookSubscription, error) {
+	return service.repo.ListSubscriptions(ctx)
+}
+
+// Unsubscribe удаляет подписку вместе с недоставленными событиями.
+func (service *WebhookService) Unsubscribe(ctx context.Context, id int64) error {
+	return service.repo.DeleteSubscription(ctx, id)
+}
+
+// DeadLetters возвращает доставки, исчерпавшие попытки, от новых к старым.
+func (service *WebhookService) DeadLetters(
+	ctx context.Context,
+	subscriptionID *int64,
+	limit int,
+) ([]domain.WebhookDelivery, error) {
+	return service.repo.ListDeadLetters(ctx, subscriptionID, limit)
+}
+
+// DeliverDue отправляет до limit наступивших доставок и сохраняет результат каждой попытки:
+// неудачная попытка откладывается по domain.WebhookBackoff или переводит доставку в dead letters.
+// Доставки выбираются по одной, чтобы аренда не истекала, пока отправляются предыдущие.
+// Возвращает число обработанных доставок.
+func (service *WebhookService) DeliverDue(ctx context.Context, limit int) (int, error) {
+	delivered := 0
+	for delivered < limit {
+		deliveries, err := service.repo.ClaimDue(ctx, service.now(), webhookClaimLease, 1)
+		if err != nil {
+			return delivered, err
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		delivery := deliveries[0]
+		if err := service.sender.Send(ctx, delivery); err != nil {
+			delivery.RecordFailure(service.now(), err.Error())
+		} else {
+			delivery.RecordSuccess(service.now())
+		}
+
+		if err := service.repo.SaveAttempt(ctx, delivery); err != nil {
+			return delivered, err
+		}
+		delivered++
+	}
+
+	return delivered, nil
+}
+
+// webhookPayload - JSON-тело вебхука о событии назначения.
+type webhookPayload struct {
+	EventID            int64   `json:"event_id"`
+	EventType          string  `json:"event_type"`
+	PullRequestID      string  `json:"pull_request_id"`
+	ReviewerID         *string `json:"reviewer_id,omitempty"`
+	PreviousReviewerID *string `json:"previous_reviewer_id,omitempty"`
+	ActorID            *string `json:"actor_id,omitempty"`
+	Reason             string  `json:"reason"`
+	OccurredAt         string  `json:"occurred_at"`
+}
+
+// WebhookOutbox дополняет историю назначений outbox'ом вебхуков: в той же транзакции, что и события,
+// записываются их доставки всем подходящим подпискам.
+type WebhookOutbox struct {
+	events   AssignmentEventRepository
+	webhooks WebhookRepository
+}
+
+func NewWebhookOutbox(events AssignmentEventRepository, webhooks WebhookRepository) *WebhookOutbox {
+	return &WebhookOutbox{
+		events:   events,
+		webhooks: webhooks,
+	}
+}
+
+// Append добавляет события в историю и ставит их в очередь подписчикам.
+func (outbox *WebhookOutbox) Append(ctx context.Context, events []domain.AssignmentEvent) error {
+	if err := outbox.events.Append(ctx, events); err != nil {
+		return err
+	}
+
+	subscriptions, err := outbox.webhooks.ListSubscriptions(ctx)
+	if err != nil || len(subscriptions) == 0 {
+		return err
+	}
+
+	var deliveries []domain.WebhookDelivery
+	for _, event := range events {
+		payload, err := json.Marshal(webhookPayload{
+			EventID:            event.ID,
+			EventType:          string(event.Type),
+			PullRequestID:      event.PullRequestID,
+			ReviewerID:         event.ReviewerID,
+			PreviousReviewerID: event.PreviousReviewerID,
+			ActorID:            event.ActorID,
+			Reason:             event.Reason,
+			OccurredAt:         event.CreatedAt.UTC().Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, subscription := range subscriptions {
+			if !subscription.Matches(event.Type) {
+				continue
+			}
+			deliveries = append(deliveries, domain.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventID:        event.ID,
+				EventType:      event.Type,
+				Payload:        payload,
+			})
+		}
+	}
+
+	return outbox.webhooks.Enqueue(ctx, deliveries)
+}
+
+// ListByPR возвращает историю назначений PR.
+func (outbox *WebhookOutbox) ListByPR(ctx context.Context, prID string) ([]domain.AssignmentEvent, error) {
+	return outbox.events.ListByPR(ctx, prID)
+}
@@ -0,0 +1,2 @@
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_selection_strategy_check;
+ALTER TABLE users.teams DROP COLUMN IF EXISTS selection_strategy;
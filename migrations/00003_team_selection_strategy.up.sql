@@ -0,0 +1,6 @@
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS selection_strategy VARCHAR(32) NOT NULL DEFAULT 'LEAST_LOADED';
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_selection_strategy_check
+        CHECK (selection_strategy IN ('LEAST_LOADED', 'ROUND_ROBIN', 'WEIGHTED_RANDOM', 'LEAST_OPEN_REVIEWS'));
@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS prs.idx_pr_reviewers_user_assigned_at;
+
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_load_half_life_hours_check;
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_load_window_hours_check;
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_load_metric_check;
+
+ALTER TABLE users.teams
+    DROP COLUMN IF EXISTS load_half_life_hours,
+    DROP COLUMN IF EXISTS load_window_hours,
+    DROP COLUMN IF EXISTS load_metric;
@@ -0,0 +1,13 @@
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS load_metric VARCHAR(16) NOT NULL DEFAULT 'ALL_TIME',
+    ADD COLUMN IF NOT EXISTS load_window_hours INT NOT NULL DEFAULT 720,
+    ADD COLUMN IF NOT EXISTS load_half_life_hours INT NOT NULL DEFAULT 168;
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_load_metric_check
+        CHECK (load_metric IN ('ALL_TIME', 'OPEN', 'WINDOW', 'DECAY')),
+    ADD CONSTRAINT teams_load_window_hours_check CHECK (load_window_hours > 0),
+    ADD CONSTRAINT teams_load_half_life_hours_check CHECK (load_half_life_hours > 0);
+
+CREATE INDEX IF NOT EXISTS idx_pr_reviewers_user_assigned_at
+    ON prs.pr_reviewers(user_id, assigned_at);
@@ -0,0 +1,5 @@
+ALTER TABLE prs.pull_requests DROP CONSTRAINT IF EXISTS pull_requests_required_reviewers_check;
+ALTER TABLE prs.pull_requests DROP COLUMN IF EXISTS required_reviewers;
+
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_required_reviewers_check;
+ALTER TABLE users.teams DROP COLUMN IF EXISTS required_reviewers;
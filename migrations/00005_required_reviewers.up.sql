@@ -0,0 +1,11 @@
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS required_reviewers INT NOT NULL DEFAULT 2;
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_required_reviewers_check CHECK (required_reviewers BETWEEN 1 AND 10);
+
+ALTER TABLE prs.pull_requests
+    ADD COLUMN IF NOT EXISTS required_reviewers INT NOT NULL DEFAULT 2;
+
+ALTER TABLE prs.pull_requests
+    ADD CONSTRAINT pull_requests_required_reviewers_check CHECK (required_reviewers BETWEEN 1 AND 10);
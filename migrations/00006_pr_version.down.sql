@@ -0,0 +1 @@
+ALTER TABLE prs.pull_requests DROP COLUMN IF EXISTS version;
@@ -0,0 +1,2 @@
+ALTER TABLE prs.pull_requests
+    ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS assignment_events_append_only ON prs.assignment_events;
+DROP FUNCTION IF EXISTS prs.assignment_events_append_only();
+
+DROP INDEX IF EXISTS prs.idx_assignment_events_pr_id;
+DROP TABLE IF EXISTS prs.assignment_events;
+
+DROP TYPE IF EXISTS prs.assignment_event_type;
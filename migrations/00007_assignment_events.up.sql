@@ -0,0 +1,34 @@
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'assignment_event_type') THEN
+        CREATE TYPE prs.assignment_event_type AS ENUM ('ASSIGNED', 'UNASSIGNED', 'REASSIGNED', 'MERGED');
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS prs.assignment_events (
+    id BIGSERIAL PRIMARY KEY,
+    pr_id VARCHAR(255) NOT NULL
+        REFERENCES prs.pull_requests(id) ON DELETE RESTRICT,
+    event_type prs.assignment_event_type NOT NULL,
+    reviewer_id VARCHAR(255)
+        REFERENCES users.users(id) ON DELETE RESTRICT,
+    previous_reviewer_id VARCHAR(255)
+        REFERENCES users.users(id) ON DELETE RESTRICT,
+    actor_id VARCHAR(255),
+    reason TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_assignment_events_pr_id ON prs.assignment_events(pr_id, id);
+
+CREATE OR REPLACE FUNCTION prs.assignment_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'prs.assignment_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS assignment_events_append_only ON prs.assignment_events;
+
+CREATE TRIGGER assignment_events_append_only
+    BEFORE UPDATE OR DELETE ON prs.assignment_events
+    FOR EACH ROW EXECUTE FUNCTION prs.assignment_events_append_only();
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS users.team_fallbacks (
+    team_id BIGINT NOT NULL
+        REFERENCES users.teams(id) ON DELETE CASCADE,
+    fallback_team_id BIGINT NOT NULL
+        REFERENCES users.teams(id) ON DELETE CASCADE,
+    priority INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (team_id, fallback_team_id),
+    CONSTRAINT team_fallbacks_not_self CHECK (team_id <> fallback_team_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS prs.idx_pr_status;
+DROP INDEX IF EXISTS prs.idx_pr_created_at_id;
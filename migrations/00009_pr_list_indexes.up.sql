@@ -0,0 +1,5 @@
+CREATE INDEX IF NOT EXISTS idx_pr_created_at_id
+    ON prs.pull_requests(created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_pr_status
+    ON prs.pull_requests(status);
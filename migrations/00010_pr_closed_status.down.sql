@@ -0,0 +1,22 @@
+-- значения enum нельзя удалить: типы пересоздаются без CLOSED/REOPENED
+
+UPDATE prs.pull_requests SET status = 'OPEN' WHERE status = 'CLOSED';
+ALTER TABLE prs.pull_requests DROP COLUMN IF EXISTS closed_at;
+
+ALTER TYPE prs.pr_status RENAME TO pr_status_old;
+CREATE TYPE prs.pr_status AS ENUM ('OPEN', 'MERGED');
+ALTER TABLE prs.pull_requests
+    ALTER COLUMN status DROP DEFAULT,
+    ALTER COLUMN status TYPE prs.pr_status USING status::text::prs.pr_status,
+    ALTER COLUMN status SET DEFAULT 'OPEN';
+DROP TYPE prs.pr_status_old;
+
+ALTER TABLE prs.assignment_events DISABLE TRIGGER assignment_events_append_only;
+DELETE FROM prs.assignment_events WHERE event_type IN ('CLOSED', 'REOPENED');
+ALTER TABLE prs.assignment_events ENABLE TRIGGER assignment_events_append_only;
+
+ALTER TYPE prs.assignment_event_type RENAME TO assignment_event_type_old;
+CREATE TYPE prs.assignment_event_type AS ENUM ('ASSIGNED', 'UNASSIGNED', 'REASSIGNED', 'MERGED');
+ALTER TABLE prs.assignment_events
+    ALTER COLUMN event_type TYPE prs.assignment_event_type USING event_type::text::prs.assignment_event_type;
+DROP TYPE prs.assignment_event_type_old;
@@ -0,0 +1,7 @@
+ALTER TYPE prs.pr_status ADD VALUE IF NOT EXISTS 'CLOSED';
+
+ALTER TYPE prs.assignment_event_type ADD VALUE IF NOT EXISTS 'CLOSED';
+ALTER TYPE prs.assignment_event_type ADD VALUE IF NOT EXISTS 'REOPENED';
+
+ALTER TABLE prs.pull_requests
+    ADD COLUMN IF NOT EXISTS closed_at timestamptz;
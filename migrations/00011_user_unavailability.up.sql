@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS users.unavailability (
+    id BIGSERIAL PRIMARY KEY,
+    user_id VARCHAR(255) NOT NULL
+        REFERENCES users.users(id) ON DELETE CASCADE,
+    starts_at timestamptz NOT NULL,
+    ends_at timestamptz NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT unavailability_period CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_unavailability_user_period
+    ON users.unavailability(user_id, starts_at, ends_at);
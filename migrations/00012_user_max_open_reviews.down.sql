@@ -0,0 +1,2 @@
+ALTER TABLE users.users DROP CONSTRAINT IF EXISTS users_max_open_reviews_positive;
+ALTER TABLE users.users DROP COLUMN IF EXISTS max_open_reviews;
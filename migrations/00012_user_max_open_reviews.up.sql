@@ -0,0 +1,7 @@
+ALTER TABLE users.users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INT;
+
+ALTER TABLE users.users
+    DROP CONSTRAINT IF EXISTS users_max_open_reviews_positive;
+ALTER TABLE users.users
+    ADD CONSTRAINT users_max_open_reviews_positive CHECK (max_open_reviews > 0);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS users.code_owners (
+    id BIGSERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL
+        REFERENCES users.teams(id) ON DELETE CASCADE,
+    position INT NOT NULL,
+    pattern TEXT NOT NULL,
+    owner_user_id VARCHAR(255)
+        REFERENCES users.users(id) ON DELETE CASCADE,
+    owner_team_id BIGINT
+        REFERENCES users.teams(id) ON DELETE CASCADE,
+    CONSTRAINT code_owners_position UNIQUE (team_id, position),
+    CONSTRAINT code_owners_single_owner CHECK ((owner_user_id IS NULL) <> (owner_team_id IS NULL))
+);
@@ -0,0 +1,2 @@
+ALTER TABLE prs.pull_requests DROP COLUMN IF EXISTS tags;
+ALTER TABLE users.users DROP COLUMN IF EXISTS tags;
@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS users.reviewer_rules (
+    id BIGSERIAL PRIMARY KEY,
+    team_id BIGINT NOT NULL
+        REFERENCES users.teams(id) ON DELETE CASCADE,
+    rule_type VARCHAR(32) NOT NULL,
+    author_id VARCHAR(255) NOT NULL
+        REFERENCES users.users(id) ON DELETE CASCADE,
+    reviewer_id VARCHAR(255)
+        REFERENCES users.users(id) ON DELETE CASCADE,
+    tag TEXT,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    CONSTRAINT reviewer_rules_type_check CHECK (
+        rule_type = 'NEVER_REVIEWS' AND reviewer_id IS NOT NULL AND tag IS NULL
+        OR rule_type = 'REQUIRES_TAG' AND tag IS NOT NULL AND reviewer_id IS NULL
+    )
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_rules_team_author
+    ON users.reviewer_rules(team_id, author_id);
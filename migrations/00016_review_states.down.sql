@@ -0,0 +1,6 @@
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_required_approvals_check;
+ALTER TABLE users.teams DROP COLUMN IF EXISTS required_approvals;
+
+ALTER TABLE prs.pr_reviewers DROP CONSTRAINT IF EXISTS pr_reviewers_state_check;
+ALTER TABLE prs.pr_reviewers DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE prs.pr_reviewers DROP COLUMN IF EXISTS state;
@@ -0,0 +1,13 @@
+ALTER TABLE prs.pr_reviewers
+    ADD COLUMN IF NOT EXISTS state VARCHAR(32) NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS reviewed_at timestamptz;
+
+ALTER TABLE prs.pr_reviewers
+    ADD CONSTRAINT pr_reviewers_state_check
+        CHECK (state IN ('PENDING', 'APPROVED', 'CHANGES_REQUESTED', 'DISMISSED'));
+
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS required_approvals INT NOT NULL DEFAULT 0;
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_required_approvals_check CHECK (required_approvals BETWEEN 0 AND 10);
@@ -0,0 +1,2 @@
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_review_sla_hours_check;
+ALTER TABLE users.teams DROP COLUMN IF EXISTS review_sla_hours;
@@ -0,0 +1,5 @@
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS review_sla_hours INT;
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_review_sla_hours_check CHECK (review_sla_hours > 0);
@@ -0,0 +1,2 @@
+ALTER TABLE users.teams DROP CONSTRAINT IF EXISTS teams_auto_reassign_hours_check;
+ALTER TABLE users.teams DROP COLUMN IF EXISTS auto_reassign_hours;
@@ -0,0 +1,5 @@
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS auto_reassign_hours INT;
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_auto_reassign_hours_check CHECK (auto_reassign_hours > 0);
@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS prs.webhook_dead_letters;
+
+DROP INDEX IF EXISTS prs.idx_webhook_deliveries_due;
+DROP TABLE IF EXISTS prs.webhook_deliveries;
+DROP TABLE IF EXISTS prs.webhook_subscriptions;
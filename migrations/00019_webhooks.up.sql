@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS prs.webhook_subscriptions (
+    id BIGSERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS prs.webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT NOT NULL
+        REFERENCES prs.webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id BIGINT NOT NULL
+        REFERENCES prs.assignment_events(id) ON DELETE RESTRICT,
+    event_type VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(32) NOT NULL DEFAULT 'PENDING',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT NOW(),
+    last_attempt_at timestamptz,
+    last_error TEXT,
+    created_at timestamptz NOT NULL DEFAULT NOW(),
+    delivered_at timestamptz,
+    CONSTRAINT webhook_deliveries_event UNIQUE (subscription_id, event_id),
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('PENDING', 'DELIVERED', 'DEAD'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON prs.webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
+
+CREATE OR REPLACE VIEW prs.webhook_dead_letters AS
+SELECT
+    d.id,
+    d.subscription_id,
+    s.url,
+    d.event_id,
+    d.event_type,
+    d.payload,
+    d.attempts,
+    d.last_error,
+    d.created_at,
+    d.last_attempt_at
+FROM prs.webhook_deliveries d
+JOIN prs.webhook_subscriptions s ON s.id = d.subscription_id
+WHERE d.status = 'DEAD';
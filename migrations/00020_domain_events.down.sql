@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS domain_events_append_only ON events.domain_events;
+DROP FUNCTION IF EXISTS events.domain_events_append_only();
+
+DROP INDEX IF EXISTS events.idx_domain_events_cursor;
+DROP TABLE IF EXISTS events.domain_events;
+
+DROP SCHEMA IF EXISTS events;
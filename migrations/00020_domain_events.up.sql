@@ -0,0 +1,25 @@
+CREATE SCHEMA IF NOT EXISTS events;
+
+-- tx_id - транзакция, записавшая событие: читатели видят событие только после завершения
+-- всех более ранних транзакций, поэтому курсор (tx_id, id) не пропускает поздно закоммиченные события
+CREATE TABLE IF NOT EXISTS events.domain_events (
+    id BIGSERIAL PRIMARY KEY,
+    tx_id BIGINT NOT NULL DEFAULT pg_current_xact_id()::text::bigint,
+    event_type VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_events_cursor ON events.domain_events(tx_id, id);
+
+CREATE OR REPLACE FUNCTION events.domain_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'events.domain_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS domain_events_append_only ON events.domain_events;
+
+CREATE TRIGGER domain_events_append_only
+    BEFORE UPDATE OR DELETE ON events.domain_events
+    FOR EACH ROW EXECUTE FUNCTION events.domain_events_append_only();
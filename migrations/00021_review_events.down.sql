@@ -0,0 +1,15 @@
+-- значение enum нельзя удалить: тип пересоздаётся без REVIEWED;
+-- REVIEW_SUBMITTED в events.domain_events остаются - поток событий append-only
+
+DELETE FROM prs.webhook_deliveries WHERE event_type = 'REVIEWED';
+UPDATE prs.webhook_subscriptions SET event_types = array_remove(event_types, 'REVIEWED');
+
+ALTER TABLE prs.assignment_events DISABLE TRIGGER assignment_events_append_only;
+DELETE FROM prs.assignment_events WHERE event_type = 'REVIEWED';
+ALTER TABLE prs.assignment_events ENABLE TRIGGER assignment_events_append_only;
+
+ALTER TYPE prs.assignment_event_type RENAME TO assignment_event_type_old;
+CREATE TYPE prs.assignment_event_type AS ENUM ('ASSIGNED', 'UNASSIGNED', 'REASSIGNED', 'MERGED', 'CLOSED', 'REOPENED');
+ALTER TABLE prs.assignment_events
+    ALTER COLUMN event_type TYPE prs.assignment_event_type USING event_type::text::prs.assignment_event_type;
+DROP TYPE prs.assignment_event_type_old;
@@ -0,0 +1 @@
+ALTER TYPE prs.assignment_event_type ADD VALUE IF NOT EXISTS 'REVIEWED';
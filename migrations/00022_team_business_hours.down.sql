@@ -0,0 +1,7 @@
+ALTER TABLE users.teams
+    DROP CONSTRAINT IF EXISTS teams_business_day_check;
+
+ALTER TABLE users.teams
+    DROP COLUMN IF EXISTS business_time_zone,
+    DROP COLUMN IF EXISTS business_day_end,
+    DROP COLUMN IF EXISTS business_day_start;
@@ -0,0 +1,8 @@
+ALTER TABLE users.teams
+    ADD COLUMN IF NOT EXISTS business_day_start SMALLINT NOT NULL DEFAULT 9,
+    ADD COLUMN IF NOT EXISTS business_day_end SMALLINT NOT NULL DEFAULT 18,
+    ADD COLUMN IF NOT EXISTS business_time_zone TEXT NOT NULL DEFAULT 'UTC';
+
+ALTER TABLE users.teams
+    ADD CONSTRAINT teams_business_day_check
+        CHECK (business_day_start >= 0 AND business_day_start < business_day_end AND business_day_end <= 24);
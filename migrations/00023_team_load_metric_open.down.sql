@@ -0,0 +1,3 @@
+-- Команды, переведённые на OPEN, не возвращаются на ALL_TIME: явно выбранный OPEN от них не отличить.
+ALTER TABLE users.teams
+    ALTER COLUMN load_metric SET DEFAULT 'ALL_TIME';
@@ -0,0 +1,6 @@
+ALTER TABLE users.teams
+    ALTER COLUMN load_metric SET DEFAULT 'OPEN';
+
+UPDATE users.teams
+SET load_metric = 'OPEN'
+WHERE load_metric = 'ALL_TIME';